@@ -8,32 +8,49 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"taskflow-api/internal/config"
+	"taskflow-api/internal/handlers"
+	"taskflow-api/internal/jobs"
+	"taskflow-api/internal/mailer"
+	"taskflow-api/internal/middleware"
 	"taskflow-api/internal/models"
 	"taskflow-api/internal/routes"
+	"taskflow-api/internal/tracing"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.LoadConfig()
 
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+
 	// Initialize database
 	db, err := initDatabase(cfg)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		log.Fatal("Failed to register database tracing:", err)
+	}
+
 	// Database migrations are handled by external tool (goose)
 	log.Println("✅ Database connection established")
 
 	// Initialize Fiber app
-	app := fiber.New(fiber.Config{
+	fiberConfig := fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			if e, ok := err.(*fiber.Error); ok {
@@ -41,17 +58,52 @@ func main() {
 			}
 
 			return c.Status(code).JSON(models.ErrorResponse{
-				Error:   "Error",
-				Message: err.Error(),
-				Code:    code,
+				Error:     "Error",
+				Message:   err.Error(),
+				Code:      code,
+				ErrorCode: models.ErrorCodeForStatus(code),
+				TraceID:   middleware.GetTraceIDFromContext(c),
 			})
 		},
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
-	})
+		// Raised to fit avatar uploads (plus multipart overhead); other routes
+		// are held to cfg.BodyLimit by middleware
+		BodyLimit: max(cfg.BodyLimit, cfg.Storage.AvatarMaxSize+64*1024),
+	}
+
+	// Only honor forwarded client IPs when they come from a trusted proxy
+	if len(cfg.TrustedProxies) > 0 {
+		fiberConfig.EnableTrustedProxyCheck = true
+		fiberConfig.TrustedProxies = cfg.TrustedProxies
+		fiberConfig.ProxyHeader = cfg.ProxyHeader
+	}
+
+	app := fiber.New(fiberConfig)
+
+	// Outgoing email is queued and sent by background workers
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		log.Fatalf("❌ Invalid mail configuration: %v", err)
+	}
+	mailQueue := mailer.NewQueue(mail, cfg.Mail.QueueSize)
+
+	// Task views are written in the background too
+	taskViews := handlers.NewTaskViewRecorder(db)
 
 	// Setup routes
-	routes.SetupRoutes(app, db, cfg)
+	routes.SetupRoutes(app, db, cfg, mailQueue, taskViews)
+
+	// Start background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	go mailQueue.Run(jobsCtx, cfg.Mail.Workers)
+	go taskViews.Run(jobsCtx)
+	if cfg.Tasks.EscalationInterval > 0 {
+		go jobs.RunEscalation(jobsCtx, db, cfg.Tasks.EscalationInterval)
+	}
+	if cfg.Tasks.OverdueNoticeInterval > 0 {
+		go jobs.RunOverdueNotices(jobsCtx, db, mailQueue, cfg.Tasks.OverdueNoticeInterval)
+	}
 
 	// Graceful shutdown
 	c := make(chan os.Signal, 1)
@@ -61,12 +113,20 @@ func main() {
 		<-c
 		log.Println("Gracefully shutting down...")
 
+		// Stop background jobs
+		stopJobs()
+
 		// Close database connection
 		sqlDB, err := db.DB()
 		if err == nil {
 			sqlDB.Close()
 		}
 
+		// Flush pending spans
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownTracing(ctx)
+
 		// Shutdown server
 		app.Shutdown()
 	}()
@@ -81,16 +141,20 @@ func main() {
 	}
 }
 
-func initDatabase(cfg *config.Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf(
+func buildDSN(cfg *config.Config, host, port string) string {
+	return fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
-		cfg.Database.Host,
+		host,
 		cfg.Database.User,
 		cfg.Database.Password,
 		cfg.Database.Name,
-		cfg.Database.Port,
+		port,
 		cfg.Database.SSLMode,
 	)
+}
+
+func initDatabase(cfg *config.Config) (*gorm.DB, error) {
+	dsn := buildDSN(cfg, cfg.Database.Host, cfg.Database.Port)
 
 	gormConfig := &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
@@ -100,6 +164,47 @@ func initDatabase(cfg *config.Config) (*gorm.DB, error) {
 		gormConfig.Logger = logger.Default.LogMode(logger.Error)
 	}
 
+	maxAttempts := cfg.Database.ConnectMaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	interval := cfg.Database.ConnectRetryInterval
+
+	// Retry with exponential backoff in case the database isn't ready yet
+	var db *gorm.DB
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		db, err = connectDatabase(dsn, gormConfig)
+		if err == nil {
+			break
+		}
+
+		if attempt == maxAttempts {
+			return nil, err
+		}
+
+		log.Printf("⏳ Database connection attempt %d/%d failed: %v (retrying in %s)", attempt, maxAttempts, err, interval)
+		time.Sleep(interval)
+		interval *= 2
+	}
+
+	// Route reads to the replica when one is configured; writes stay on the primary
+	if cfg.Database.ReplicaHost != "" {
+		replicaDSN := buildDSN(cfg, cfg.Database.ReplicaHost, cfg.Database.ReplicaPort)
+		if err := db.Use(dbresolver.Register(dbresolver.Config{
+			Replicas: []gorm.Dialector{postgres.Open(replicaDSN)},
+			Policy:   dbresolver.RandomPolicy{},
+		})); err != nil {
+			return nil, err
+		}
+		log.Printf("✅ Read replica configured at %s:%s", cfg.Database.ReplicaHost, cfg.Database.ReplicaPort)
+	}
+
+	log.Println("✅ Database connected successfully")
+	return db, nil
+}
+
+func connectDatabase(dsn string, gormConfig *gorm.Config) (*gorm.DB, error) {
 	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
 	if err != nil {
 		return nil, err
@@ -120,9 +225,9 @@ func initDatabase(cfg *config.Config) (*gorm.DB, error) {
 	defer cancel()
 
 	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
 		return nil, err
 	}
 
-	log.Println("✅ Database connected successfully")
 	return db, nil
 }
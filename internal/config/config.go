@@ -1,18 +1,56 @@
 package config
 
 import (
+	"crypto/rsa"
+	"fmt"
 	"log"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port        string
-	Environment string
-	Database    DatabaseConfig
-	JWT         JWTConfig
+	Port             string
+	Environment      string
+	BodyLimit        int
+	CompressionLevel int
+	TrustedProxies   []string
+	ProxyHeader      string
+	CORS             CORSConfig
+	Database         DatabaseConfig
+	JWT              JWTConfig
+	Projects         ProjectConfig
+	Tasks            TaskConfig
+	Pagination       PaginationConfig
+	Tracing          TracingConfig
+	Password         PasswordPolicy
+	Users            UserConfig
+	Storage          StorageConfig
+	Mail             MailConfig
+	RateLimit        RateLimitConfig
+
+	// Features switches capabilities on or off per environment; see FeatureEnabled
+	Features map[string]bool
+
+	// Request deadlines; slow routes such as the dashboard use the longer one
+	RequestTimeout     time.Duration
+	SlowRequestTimeout time.Duration
+
+	// Requests taking longer than this are logged as warnings; zero disables it
+	RequestBudget time.Duration
+}
+
+type CORSConfig struct {
+	AllowOrigins []string
+	AllowMethods []string
+	AllowHeaders []string
 }
 
 type DatabaseConfig struct {
@@ -22,11 +60,207 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+
+	ConnectMaxAttempts   int
+	ConnectRetryInterval time.Duration
+
+	// Optional read replica; reads are routed here when ReplicaHost is set
+	ReplicaHost string
+	ReplicaPort string
 }
 
 type JWTConfig struct {
-	Secret string
-	Expiry string
+	// Signing algorithm: HS256 signs with Secret, RS256 with PrivateKey
+	Algorithm string
+
+	Secret           string
+	Expiry           string
+	RememberMeExpiry string
+	VerifyUser       bool
+
+	// Lifetime of tokens admins issue to impersonate a user
+	ImpersonationExpiry string
+
+	// Whether logins, registrations and account changes are written to the
+	// auth_events audit table
+	LogAuthEvents bool
+
+	// RS256 key pair, each given as PEM or a path to a PEM file. The public
+	// key defaults to the private key's. Parsed at startup by validateJWTKeys.
+	PrivateKeyPEM string
+	PublicKeyPEM  string
+	PrivateKey    *rsa.PrivateKey
+	PublicKey     *rsa.PublicKey
+}
+
+type ProjectConfig struct {
+	// Maximum projects a user may own; zero means unlimited. Users can be
+	// given their own limit through users.project_quota.
+	MaxPerUser int
+
+	// Whether archived projects count towards the quota
+	QuotaCountsArchived bool
+
+	// Maximum lengths in characters accepted for names and descriptions
+	MaxNameLength        int
+	MaxDescriptionLength int
+
+	// Colors projects may use; empty allows any hex color
+	ColorPalette []string
+
+	// How long task endpoints may reuse a project ownership check; zero
+	// disables the cache
+	OwnershipCacheTTL time.Duration
+}
+
+type TaskConfig struct {
+	BulkMaxSize int
+
+	// Maximum non-deleted tasks per project; zero means unlimited
+	MaxPerProject int
+
+	// Maximum lengths in characters accepted for titles and descriptions
+	MaxTitleLength       int
+	MaxDescriptionLength int
+
+	// How often overdue tasks are escalated; zero disables the job
+	EscalationInterval time.Duration
+
+	// How often assignees are emailed about newly overdue tasks; zero
+	// disables the job
+	OverdueNoticeInterval time.Duration
+
+	// Reject new tasks whose due date has already passed unless the request
+	// sets allow_past_due_date, e.g. when backfilling
+	RejectPastDueDate bool
+
+	// How many days ahead a due date may be set, to catch mistyped years;
+	// zero allows any date
+	MaxDueDateDays int
+
+	// Make task search ignore accents; needs the unaccent extension and falls
+	// back to plain ILIKE without it
+	SearchUnaccent bool
+}
+
+// PaginationConfig holds the page size used by list endpoints when the
+// client does not pass a limit
+type PaginationConfig struct {
+	DefaultUserLimit    int
+	DefaultProjectLimit int
+	DefaultTaskLimit    int
+
+	// Highest page list endpoints serve; deeper pages are rejected
+	MaxPage int
+
+	// Order used by list endpoints when the client does not pass sort/order
+	DefaultSortField     string
+	DefaultSortDirection string
+}
+
+type TracingConfig struct {
+	Endpoint    string
+	ServiceName string
+}
+
+type PasswordPolicy struct {
+	MinLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+}
+
+// JWT signing algorithms
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+)
+
+// MinJWTSecretLength is the shortest JWT secret accepted in production
+const MinJWTSecretLength = 32
+
+// insecureJWTSecrets are placeholder secrets shipped in defaults and examples
+var insecureJWTSecrets = []string{
+	"your_jwt_secret_here",
+	"your_jwt_secret_here_change_in_production",
+	"your_super_secret_jwt_key",
+}
+
+// Policies for tasks assigned to a user when that user is deleted
+const (
+	DeletedAssigneeUnassign        = "unassign"
+	DeletedAssigneeReassignToOwner = "reassign_to_owner"
+)
+
+type UserConfig struct {
+	DeletedAssigneePolicy  string
+	DeleteRequiresPassword bool
+}
+
+// Supported upload storage drivers
+const (
+	StorageDriverLocal = "local"
+	StorageDriverS3    = "s3"
+)
+
+type StorageConfig struct {
+	Driver    string
+	LocalDir  string
+	PublicURL string
+
+	// S3 or S3-compatible bucket; an empty endpoint means AWS. The session
+	// token is only set for temporary credentials, such as from STS
+	S3Bucket          string
+	S3Region          string
+	S3Endpoint        string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3SessionToken    string
+
+	// Maximum avatar upload size in bytes; may exceed BodyLimit
+	AvatarMaxSize int
+}
+
+// Supported mail drivers; the log driver only writes emails to the log
+const (
+	MailDriverLog  = "log"
+	MailDriverSMTP = "smtp"
+)
+
+type MailConfig struct {
+	Driver string
+	From   string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	// Emails are sent in the background; when the queue is full new emails
+	// are dropped rather than blocking requests
+	QueueSize int
+	Workers   int
+}
+
+// RateLimitConfig caps requests per client in a fixed window. Authenticated
+// requests are counted per user and the others per IP. A Max of 0 disables
+// the limit.
+type RateLimitConfig struct {
+	Max    int
+	Window time.Duration
+}
+
+// Feature flags. Features not listed in FEATURE_FLAGS use DefaultFeatures.
+const (
+	FeatureSSE       = "sse"
+	FeatureWebSocket = "websocket"
+)
+
+// DefaultFeatures are the flags in effect before FEATURE_FLAGS is applied
+var DefaultFeatures = map[string]bool{
+	FeatureSSE:       true,
+	FeatureWebSocket: true,
 }
 
 func LoadConfig() *Config {
@@ -36,8 +270,17 @@ func LoadConfig() *Config {
 	}
 
 	config := &Config{
-		Port:        getEnv("PORT", "8080"),
-		Environment: getEnv("ENV", "development"),
+		Port:             getEnv("PORT", "8080"),
+		Environment:      getEnv("ENV", "development"),
+		BodyLimit:        getEnvAsInt("BODY_LIMIT", 1024*1024),
+		CompressionLevel: getEnvAsInt("COMPRESSION_LEVEL", 0),
+		TrustedProxies:   getEnvAsSlice("TRUSTED_PROXIES", nil),
+		ProxyHeader:      getEnv("PROXY_HEADER", "X-Forwarded-For"),
+		CORS: CORSConfig{
+			AllowOrigins: getEnvAsSlice("CORS_ALLOW_ORIGINS", []string{"*"}),
+			AllowMethods: getEnvAsSlice("CORS_ALLOW_METHODS", []string{"GET", "POST", "HEAD", "PUT", "DELETE", "PATCH"}),
+			AllowHeaders: getEnvAsSlice("CORS_ALLOW_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key"}),
+		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5433"),
@@ -45,16 +288,338 @@ func LoadConfig() *Config {
 			Password: getEnv("DB_PASSWORD", "password"),
 			Name:     getEnv("DB_NAME", "taskflow"),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+
+			ConnectMaxAttempts:   getEnvAsInt("DB_CONNECT_MAX_ATTEMPTS", 5),
+			ConnectRetryInterval: getEnvAsDuration("DB_CONNECT_RETRY_INTERVAL", time.Second),
+
+			ReplicaHost: getEnv("DB_REPLICA_HOST", ""),
+			ReplicaPort: getEnv("DB_REPLICA_PORT", getEnv("DB_PORT", "5433")),
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "your_jwt_secret_here"),
-			Expiry: getEnv("JWT_EXPIRY", "24h"),
+			Algorithm:        strings.ToUpper(getEnv("JWT_ALGORITHM", JWTAlgorithmHS256)),
+			Secret:           getEnv("JWT_SECRET", "your_jwt_secret_here"),
+			Expiry:           getEnv("JWT_EXPIRY", "24h"),
+			RememberMeExpiry: getEnv("JWT_REMEMBER_ME_EXPIRY", "720h"),
+			VerifyUser:       getEnvAsBool("AUTH_VERIFY_USER", true),
+
+			ImpersonationExpiry: getEnv("JWT_IMPERSONATION_EXPIRY", "15m"),
+			LogAuthEvents:       getEnvAsBool("AUTH_EVENT_LOG", true),
+			PrivateKeyPEM:       getEnv("JWT_PRIVATE_KEY", ""),
+			PublicKeyPEM:        getEnv("JWT_PUBLIC_KEY", ""),
+		},
+		Projects: ProjectConfig{
+			MaxPerUser:          getEnvAsInt("PROJECT_MAX_PER_USER", 0),
+			QuotaCountsArchived: getEnvAsBool("PROJECT_QUOTA_COUNTS_ARCHIVED", false),
+
+			MaxNameLength:        getEnvAsInt("PROJECT_MAX_NAME_LENGTH", MaxNameColumnLength),
+			MaxDescriptionLength: getEnvAsInt("PROJECT_MAX_DESCRIPTION_LENGTH", 10000),
+			ColorPalette:         getEnvAsSlice("PROJECT_COLOR_PALETTE", nil),
+
+			OwnershipCacheTTL: getEnvAsDuration("PROJECT_OWNERSHIP_CACHE_TTL", 10*time.Second),
+		},
+		Tasks: TaskConfig{
+			BulkMaxSize:   getEnvAsInt("TASK_BULK_MAX_SIZE", 100),
+			MaxPerProject: getEnvAsInt("TASK_MAX_PER_PROJECT", 0),
+
+			MaxTitleLength:       getEnvAsInt("TASK_MAX_TITLE_LENGTH", MaxNameColumnLength),
+			MaxDescriptionLength: getEnvAsInt("TASK_MAX_DESCRIPTION_LENGTH", 10000),
+
+			EscalationInterval:    getEnvAsDuration("TASK_ESCALATION_INTERVAL", 15*time.Minute),
+			OverdueNoticeInterval: getEnvAsDuration("TASK_OVERDUE_NOTICE_INTERVAL", 15*time.Minute),
+
+			RejectPastDueDate: getEnvAsBool("TASK_REJECT_PAST_DUE_DATE", false),
+			MaxDueDateDays:    getEnvAsInt("TASK_MAX_DUE_DATE_DAYS", 0),
+
+			SearchUnaccent: getEnvAsBool("TASK_SEARCH_UNACCENT", false),
+		},
+		Pagination: PaginationConfig{
+			DefaultUserLimit:    getEnvAsInt("PAGINATION_DEFAULT_USER_LIMIT", 10),
+			DefaultProjectLimit: getEnvAsInt("PAGINATION_DEFAULT_PROJECT_LIMIT", 10),
+			DefaultTaskLimit:    getEnvAsInt("PAGINATION_DEFAULT_TASK_LIMIT", 10),
+			MaxPage:             getEnvAsInt("PAGINATION_MAX_PAGE", 1000),
+
+			DefaultSortField:     getEnv("PAGINATION_DEFAULT_SORT", "created_at"),
+			DefaultSortDirection: strings.ToLower(getEnv("PAGINATION_DEFAULT_ORDER", SortDesc)),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "taskflow-api"),
+		},
+		Password: PasswordPolicy{
+			MinLength:      getEnvAsInt("PASSWORD_MIN_LENGTH", 6),
+			RequireUpper:   getEnvAsBool("PASSWORD_REQUIRE_UPPER", false),
+			RequireLower:   getEnvAsBool("PASSWORD_REQUIRE_LOWER", false),
+			RequireDigit:   getEnvAsBool("PASSWORD_REQUIRE_DIGIT", false),
+			RequireSpecial: getEnvAsBool("PASSWORD_REQUIRE_SPECIAL", false),
 		},
+		Users: UserConfig{
+			DeletedAssigneePolicy:  getEnv("USER_DELETED_ASSIGNEE_POLICY", DeletedAssigneeUnassign),
+			DeleteRequiresPassword: getEnvAsBool("USER_DELETE_REQUIRES_PASSWORD", true),
+		},
+		Storage: StorageConfig{
+			Driver:        getEnv("STORAGE_DRIVER", StorageDriverLocal),
+			LocalDir:      getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+			PublicURL:     getEnv("STORAGE_PUBLIC_URL", "/uploads"),
+			AvatarMaxSize: getEnvAsInt("AVATAR_MAX_SIZE", 2*1024*1024),
+
+			S3Bucket:          getEnv("STORAGE_S3_BUCKET", ""),
+			S3Region:          getEnv("STORAGE_S3_REGION", "us-east-1"),
+			S3Endpoint:        getEnv("STORAGE_S3_ENDPOINT", ""),
+			S3AccessKeyID:     getEnv("STORAGE_S3_ACCESS_KEY_ID", ""),
+			S3SecretAccessKey: getEnv("STORAGE_S3_SECRET_ACCESS_KEY", ""),
+			S3SessionToken:    getEnv("STORAGE_S3_SESSION_TOKEN", ""),
+		},
+		Mail: MailConfig{
+			Driver: getEnv("MAIL_DRIVER", MailDriverLog),
+			From:   getEnv("MAIL_FROM", "TaskFlow <no-reply@taskflow.local>"),
+
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+
+			QueueSize: getEnvAsInt("MAIL_QUEUE_SIZE", 100),
+			Workers:   getEnvAsInt("MAIL_WORKERS", 2),
+		},
+		RateLimit: RateLimitConfig{
+			Max:    getEnvAsInt("RATE_LIMIT_MAX", 300),
+			Window: getEnvAsDuration("RATE_LIMIT_WINDOW", time.Minute),
+		},
+
+		Features: getEnvAsFlags("FEATURE_FLAGS", DefaultFeatures),
+
+		RequestTimeout:     getEnvAsDuration("REQUEST_TIMEOUT", 30*time.Second),
+		SlowRequestTimeout: getEnvAsDuration("REQUEST_SLOW_TIMEOUT", 2*time.Minute),
+		RequestBudget:      getEnvAsDuration("REQUEST_BUDGET", time.Second),
 	}
 
+	config.validateJWTKeys()
+	config.validateJWTExpiry()
+	config.validateCORS()
+	config.validatePagination()
+	config.validateTextLimits()
+	config.validateColorPalette()
+	config.validateRateLimit()
+	config.validateUsers()
+
 	return config
 }
 
+// FeatureEnabled reports whether the named feature flag is on. Unknown flags
+// are off.
+func (c *Config) FeatureEnabled(name string) bool {
+	return c.Features[name]
+}
+
+// validateJWTExpiry checks the token lifetimes up front. Tokens fall back to a
+// 24 hour lifetime when these don't parse, so production refuses to start and
+// other environments log a warning.
+func (c *Config) validateJWTExpiry() {
+	settings := map[string]string{
+		"JWT_EXPIRY":               c.JWT.Expiry,
+		"JWT_REMEMBER_ME_EXPIRY":   c.JWT.RememberMeExpiry,
+		"JWT_IMPERSONATION_EXPIRY": c.JWT.ImpersonationExpiry,
+	}
+	for key, value := range settings {
+		duration, err := time.ParseDuration(value)
+		if err == nil && duration > 0 {
+			continue
+		}
+
+		if c.Environment == "production" {
+			log.Fatalf("❌ Invalid JWT configuration: %s=%q is not a positive duration such as 24h", key, value)
+		}
+		log.Printf("⚠️  WARNING: Invalid JWT configuration: %s=%q is not a positive duration such as 24h; tokens will expire after 24h", key, value)
+	}
+}
+
+// validateJWTKeys checks the signing key material for the configured
+// algorithm. A missing or unreadable RS256 key stops the application in every
+// environment, since no token could be issued or verified.
+func (c *Config) validateJWTKeys() {
+	switch c.JWT.Algorithm {
+	case JWTAlgorithmHS256:
+		c.validateJWTSecret()
+		return
+	case JWTAlgorithmRS256:
+	default:
+		log.Fatalf("❌ Invalid JWT configuration: JWT_ALGORITHM must be %s or %s", JWTAlgorithmHS256, JWTAlgorithmRS256)
+	}
+
+	if c.JWT.PrivateKeyPEM == "" {
+		log.Fatalf("❌ Invalid JWT configuration: JWT_PRIVATE_KEY is required for %s", JWTAlgorithmRS256)
+	}
+	privatePEM, err := readPEM(c.JWT.PrivateKeyPEM)
+	if err != nil {
+		log.Fatalf("❌ Invalid JWT configuration: JWT_PRIVATE_KEY: %v", err)
+	}
+	c.JWT.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+	if err != nil {
+		log.Fatalf("❌ Invalid JWT configuration: JWT_PRIVATE_KEY is not an RSA private key: %v", err)
+	}
+
+	c.JWT.PublicKey = &c.JWT.PrivateKey.PublicKey
+	if c.JWT.PublicKeyPEM == "" {
+		return
+	}
+	publicPEM, err := readPEM(c.JWT.PublicKeyPEM)
+	if err != nil {
+		log.Fatalf("❌ Invalid JWT configuration: JWT_PUBLIC_KEY: %v", err)
+	}
+	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+	if err != nil {
+		log.Fatalf("❌ Invalid JWT configuration: JWT_PUBLIC_KEY is not an RSA public key: %v", err)
+	}
+	if !publicKey.Equal(c.JWT.PublicKey) {
+		log.Fatalf("❌ Invalid JWT configuration: JWT_PUBLIC_KEY does not match JWT_PRIVATE_KEY")
+	}
+}
+
+// readPEM returns PEM given inline, with \n escapes allowed for single-line
+// environment variables, or read from the file at the given path
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(strings.ReplaceAll(value, `\n`, "\n")), nil
+	}
+	return os.ReadFile(value)
+}
+
+// validateJWTSecret stops the application in production when the JWT secret is
+// missing or weak, and logs a warning in other environments
+func (c *Config) validateJWTSecret() {
+	var problem string
+	switch {
+	case c.JWT.Secret == "":
+		problem = "JWT_SECRET is empty"
+	case slices.Contains(insecureJWTSecrets, c.JWT.Secret):
+		problem = "JWT_SECRET is set to a placeholder value"
+	case len(c.JWT.Secret) < MinJWTSecretLength:
+		problem = fmt.Sprintf("JWT_SECRET is shorter than %d characters", MinJWTSecretLength)
+	default:
+		return
+	}
+
+	if c.Environment == "production" {
+		log.Fatalf("❌ Insecure JWT configuration: %s", problem)
+	}
+
+	log.Printf("⚠️  WARNING: Insecure JWT configuration: %s. Tokens can be forged; never deploy this to production!", problem)
+}
+
+// validateCORS stops the application when a CORS setting resolves to an empty list
+func (c *Config) validateCORS() {
+	settings := map[string][]string{
+		"CORS_ALLOW_ORIGINS": c.CORS.AllowOrigins,
+		"CORS_ALLOW_METHODS": c.CORS.AllowMethods,
+		"CORS_ALLOW_HEADERS": c.CORS.AllowHeaders,
+	}
+	for key, values := range settings {
+		if len(values) == 0 {
+			log.Fatalf("❌ Invalid CORS configuration: %s must not be empty", key)
+		}
+	}
+}
+
+// MaxPageLimit is the largest page size list endpoints accept
+const MaxPageLimit = 100
+
+// MaxPageCap bounds PAGINATION_MAX_PAGE so offsets stay far from overflowing
+const MaxPageCap = 1000000
+
+// Sort directions accepted by list endpoints
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// CommonSortFields are the sort fields every list endpoint supports, so only
+// these can be the default
+var CommonSortFields = []string{"created_at", "updated_at"}
+
+// validatePagination stops the application when a default page size or the
+// maximum page is out of range or the default sort is not supported by every
+// list
+func (c *Config) validatePagination() {
+	settings := map[string]int{
+		"PAGINATION_DEFAULT_USER_LIMIT":    c.Pagination.DefaultUserLimit,
+		"PAGINATION_DEFAULT_PROJECT_LIMIT": c.Pagination.DefaultProjectLimit,
+		"PAGINATION_DEFAULT_TASK_LIMIT":    c.Pagination.DefaultTaskLimit,
+	}
+	for key, value := range settings {
+		if value < 1 || value > MaxPageLimit {
+			log.Fatalf("❌ Invalid pagination configuration: %s must be between 1 and %d", key, MaxPageLimit)
+		}
+	}
+
+	if c.Pagination.MaxPage < 1 || c.Pagination.MaxPage > MaxPageCap {
+		log.Fatalf("❌ Invalid pagination configuration: PAGINATION_MAX_PAGE must be between 1 and %d", MaxPageCap)
+	}
+
+	if !slices.Contains(CommonSortFields, c.Pagination.DefaultSortField) {
+		log.Fatalf("❌ Invalid pagination configuration: PAGINATION_DEFAULT_SORT must be one of %s", strings.Join(CommonSortFields, ", "))
+	}
+	if c.Pagination.DefaultSortDirection != SortAsc && c.Pagination.DefaultSortDirection != SortDesc {
+		log.Fatalf("❌ Invalid pagination configuration: PAGINATION_DEFAULT_ORDER must be %s or %s", SortAsc, SortDesc)
+	}
+}
+
+// validateUsers stops the application when the deleted assignee policy is unknown
+func (c *Config) validateUsers() {
+	policy := c.Users.DeletedAssigneePolicy
+	if policy != DeletedAssigneeUnassign && policy != DeletedAssigneeReassignToOwner {
+		log.Fatalf("❌ Invalid user configuration: USER_DELETED_ASSIGNEE_POLICY must be %s or %s", DeletedAssigneeUnassign, DeletedAssigneeReassignToOwner)
+	}
+}
+
+// MaxNameColumnLength is the size of the project name and task title columns
+const MaxNameColumnLength = 255
+
+// validateTextLimits stops the application when a length limit is out of range
+func (c *Config) validateTextLimits() {
+	names := map[string]int{
+		"PROJECT_MAX_NAME_LENGTH": c.Projects.MaxNameLength,
+		"TASK_MAX_TITLE_LENGTH":   c.Tasks.MaxTitleLength,
+	}
+	for key, value := range names {
+		if value < 1 || value > MaxNameColumnLength {
+			log.Fatalf("❌ Invalid length configuration: %s must be between 1 and %d", key, MaxNameColumnLength)
+		}
+	}
+
+	descriptions := map[string]int{
+		"PROJECT_MAX_DESCRIPTION_LENGTH": c.Projects.MaxDescriptionLength,
+		"TASK_MAX_DESCRIPTION_LENGTH":    c.Tasks.MaxDescriptionLength,
+	}
+	for key, value := range descriptions {
+		if value < 1 {
+			log.Fatalf("❌ Invalid length configuration: %s must be at least 1", key)
+		}
+	}
+}
+
+// HexColorPattern matches the #rgb and #rrggbb colors projects accept
+var HexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validateColorPalette stops the application when a palette entry is not a hex color
+func (c *Config) validateColorPalette() {
+	for _, color := range c.Projects.ColorPalette {
+		if !HexColorPattern.MatchString(color) {
+			log.Fatalf("❌ Invalid color palette: %q in PROJECT_COLOR_PALETTE is not a hex color", color)
+		}
+	}
+}
+
+// validateRateLimit stops the application when the rate limit is negative or
+// has no window
+func (c *Config) validateRateLimit() {
+	if c.RateLimit.Max < 0 {
+		log.Fatalf("❌ Invalid rate limit configuration: RATE_LIMIT_MAX must not be negative")
+	}
+	if c.RateLimit.Max > 0 && c.RateLimit.Window <= 0 {
+		log.Fatalf("❌ Invalid rate limit configuration: RATE_LIMIT_WINDOW must be positive")
+	}
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -79,3 +644,43 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	}
+	return defaultValue
+}
+
+// getEnvAsFlags reads comma-separated name=bool pairs over the defaults. A
+// bare name turns the flag on; entries whose value does not parse are ignored.
+func getEnvAsFlags(key string, defaults map[string]bool) map[string]bool {
+	flags := maps.Clone(defaults)
+	for _, item := range getEnvAsSlice(key, nil) {
+		name, value, hasValue := strings.Cut(item, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !hasValue {
+			flags[name] = true
+			continue
+		}
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			flags[name] = enabled
+		}
+	}
+	return flags
+}
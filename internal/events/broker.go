@@ -0,0 +1,149 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"taskflow-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Type string
+
+const (
+	TaskCreated  Type = "task.created"
+	TaskUpdated  Type = "task.updated"
+	TaskReopened Type = "task.reopened"
+	TaskDeleted  Type = "task.deleted"
+	TaskMerged   Type = "task.merged"
+
+	// A todo task was moved to in_progress because it was assigned
+	TaskAutoStarted Type = "task.auto_started"
+
+	// Project events tell subscribers the project changed hands or is gone;
+	// subscribers who no longer own it are dropped
+	ProjectTransferred Type = "project.transferred"
+	ProjectDeleted     Type = "project.deleted"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it
+// is dropped
+const subscriberBuffer = 64
+
+// Event describes a change to a task within a project, or to the project
+// itself, in which case TaskID is empty
+type Event struct {
+	Type      Type        `json:"type"`
+	ProjectID uuid.UUID   `json:"project_id"`
+	TaskID    uuid.UUID   `json:"task_id,omitzero"`
+	Data      interface{} `json:"data,omitempty"`
+	Time      time.Time   `json:"time"`
+}
+
+// NewTaskEvent builds an event for a task; deleted tasks carry no data
+func NewTaskEvent(eventType Type, task *models.Task) Event {
+	event := Event{
+		Type:      eventType,
+		ProjectID: task.ProjectID,
+		TaskID:    task.ID,
+		Time:      time.Now(),
+	}
+	if eventType != TaskDeleted {
+		event.Data = task.ToResponse()
+	}
+	return event
+}
+
+// NewProjectEvent builds an event for a project; deleted projects carry no data
+func NewProjectEvent(eventType Type, project *models.Project) Event {
+	event := Event{
+		Type:      eventType,
+		ProjectID: project.ID,
+		Time:      time.Now(),
+	}
+	if eventType != ProjectDeleted {
+		event.Data = project.ToResponse()
+	}
+	return event
+}
+
+// IsOwnershipChange reports whether subscribers must check they still own the
+// event's project before following it any further
+func (e Event) IsOwnershipChange() bool {
+	return e.Type == ProjectTransferred || e.Type == ProjectDeleted
+}
+
+// NewTaskMergedEvent builds the event for a task merged into target. It
+// carries the target so clients can follow the merge.
+func NewTaskMergedEvent(source, target *models.Task) Event {
+	event := NewTaskEvent(TaskMerged, source)
+	event.Data = target.ToResponse()
+	return event
+}
+
+// Broker is an in-process pub/sub that fans task events out to the
+// subscribers of each project
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan Event]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[uuid.UUID]map[chan Event]struct{}),
+	}
+}
+
+// Subscribe registers for a project's events. The returned channel is closed
+// when the subscriber is dropped for falling behind or after calling the
+// returned unsubscribe function.
+func (b *Broker) Subscribe(projectID uuid.UUID) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[projectID] == nil {
+		b.subscribers[projectID] = make(map[chan Event]struct{})
+	}
+	b.subscribers[projectID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(projectID, ch)
+	}
+}
+
+// Publish delivers an event to every subscriber of its project without
+// blocking. Subscribers whose buffer is full are dropped so a slow client
+// cannot hold up the handlers; they are expected to reconnect and resync.
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[event.ProjectID] {
+		select {
+		case ch <- event:
+		default:
+			b.remove(event.ProjectID, ch)
+		}
+	}
+}
+
+// remove closes and forgets a subscriber; callers must hold b.mu
+func (b *Broker) remove(projectID uuid.UUID, ch chan Event) {
+	subs, ok := b.subscribers[projectID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(b.subscribers, projectID)
+	}
+}
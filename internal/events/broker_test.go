@@ -0,0 +1,45 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"taskflow-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func TestProjectEventsTellSubscribersToRecheckOwnership(t *testing.T) {
+	project := &models.Project{ID: uuid.New()}
+	broker := NewBroker()
+	stream, unsubscribe := broker.Subscribe(project.ID)
+	defer unsubscribe()
+
+	broker.Publish(NewTaskEvent(TaskCreated, &models.Task{ID: uuid.New(), ProjectID: project.ID}))
+	broker.Publish(NewProjectEvent(ProjectTransferred, project))
+	broker.Publish(NewProjectEvent(ProjectDeleted, project))
+
+	for _, want := range []struct {
+		eventType       Type
+		ownershipChange bool
+	}{
+		{TaskCreated, false},
+		{ProjectTransferred, true},
+		{ProjectDeleted, true},
+	} {
+		event := <-stream
+		if event.Type != want.eventType || event.IsOwnershipChange() != want.ownershipChange {
+			t.Errorf("got %s (ownership change %v), want %s (%v)", event.Type, event.IsOwnershipChange(), want.eventType, want.ownershipChange)
+		}
+
+		// Project events have no task
+		payload, err := json.Marshal(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hasTask := strings.Contains(string(payload), `"task_id"`); hasTask == want.ownershipChange {
+			t.Errorf("%s payload has task_id = %v: %s", event.Type, hasTask, payload)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"time"
+
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type APIKeyHandler struct {
+	db       *gorm.DB
+	validate *validator.Validate
+}
+
+func NewAPIKeyHandler(db *gorm.DB) *APIKeyHandler {
+	return &APIKeyHandler{
+		db:       db,
+		validate: validator.New(),
+	}
+}
+
+// CreateAPIKey creates a new API key for the current user
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	var req models.APIKeyCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Validate request
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "expires_at must be in the future",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Generate key
+	key, prefix, hash, err := middleware.GenerateAPIKey()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to generate API key",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	apiKey := models.APIKey{
+		UserID:    currentUserID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    models.AllScopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	if len(req.Scopes) > 0 {
+		apiKey.Scopes = req.Scopes
+	}
+
+	if err := db.Create(&apiKey).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to create API key",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
+		Message: "API key created successfully. Store the key now; it will not be shown again",
+		Data: models.APIKeyCreatedResponse{
+			APIKeyResponse: apiKey.ToResponse(),
+			Key:            key,
+		},
+	})
+}
+
+// GetAPIKeys lists the current user's API keys
+func (h *APIKeyHandler) GetAPIKeys(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var apiKeys []models.APIKey
+	if err := db.Where("user_id = ?", currentUserID).
+		Order("created_at DESC").Find(&apiKeys).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch API keys",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Convert to response format
+	apiKeyResponses := make([]models.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		apiKeyResponses[i] = apiKey.ToResponse()
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "API keys retrieved successfully",
+		Data:    apiKeyResponses,
+	})
+}
+
+// RevokeAPIKey revokes one of the current user's API keys
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	apiKeyID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid API key ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	result := db.Where("id = ? AND user_id = ?", apiKeyID, currentUserID).
+		Delete(&models.APIKey{})
+
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to revoke API key",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "API key not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrAPIKeyNotFound,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "API key revoked successfully",
+	})
+}
@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestAssignmentNotifiesAssignee(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	token := app.Token(t, owner)
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Assigning")
+	projectTasksPath := "/api/v1/projects/" + project.ID.String() + "/tasks"
+
+	tests := []struct {
+		name   string
+		tasks  int
+		assign func(t *testing.T, assigneeID uuid.UUID) int
+	}{
+		{"create", 1, func(t *testing.T, assigneeID uuid.UUID) int {
+			return app.Do(t, fiber.MethodPost, projectTasksPath, token, fiber.Map{"title": "New", "assignee_id": assigneeID}).StatusCode
+		}},
+		{"bulk-create", 2, func(t *testing.T, assigneeID uuid.UUID) int {
+			return app.Do(t, fiber.MethodPost, projectTasksPath+"/bulk", token, []fiber.Map{
+				{"title": "Bulk new 1", "assignee_id": assigneeID},
+				{"title": "Bulk new 2", "assignee_id": assigneeID},
+			}).StatusCode
+		}},
+		{"update", 1, func(t *testing.T, assigneeID uuid.UUID) int {
+			task := testutil.CreateTask(t, app.DB, project, "Updated", nil)
+			return app.Do(t, fiber.MethodPut, "/api/v1/tasks/"+task.ID.String(), token, fiber.Map{"assignee_id": assigneeID}).StatusCode
+		}},
+		{"assignee", 1, func(t *testing.T, assigneeID uuid.UUID) int {
+			task := testutil.CreateTask(t, app.DB, project, "Reassigned", nil)
+			return app.Do(t, fiber.MethodPatch, "/api/v1/tasks/"+task.ID.String()+"/assignee", token, fiber.Map{"assignee_id": assigneeID}).StatusCode
+		}},
+		{"bulk-assign", 2, func(t *testing.T, assigneeID uuid.UUID) int {
+			first := testutil.CreateTask(t, app.DB, project, "Bulk 1", nil)
+			second := testutil.CreateTask(t, app.DB, project, "Bulk 2", nil)
+			return app.Do(t, fiber.MethodPatch, projectTasksPath+"/bulk-assign", token, fiber.Map{
+				"task_ids":    []uuid.UUID{first.ID, second.ID},
+				"assignee_id": assigneeID,
+			}).StatusCode
+		}},
+		{"clone", 1, func(t *testing.T, assigneeID uuid.UUID) int {
+			source := testutil.CreateTask(t, app.DB, project, "Cloned", nil)
+			return app.Do(t, fiber.MethodPost, "/api/v1/tasks/"+source.ID.String()+"/clone", token, fiber.Map{"assignee_id": assigneeID}).StatusCode
+		}},
+		{"reassign", 1, func(t *testing.T, assigneeID uuid.UUID) int {
+			previous := testutil.CreateUser(t, app.DB, uuid.NewString()+"@example.com")
+			testutil.CreateTask(t, app.DB, project, "Handed over", &previous.ID)
+			return app.Do(t, fiber.MethodPost, "/api/v1/users/"+previous.ID.String()+"/reassign-tasks", token, fiber.Map{"new_assignee_id": assigneeID}).StatusCode
+		}},
+		{"duplicate", 1, func(t *testing.T, assigneeID uuid.UUID) int {
+			source := testutil.CreateProject(t, app.DB, owner.ID, "Template")
+			testutil.CreateTask(t, app.DB, source, "Copied", &assigneeID)
+			return app.Do(t, fiber.MethodPost, "/api/v1/projects/"+source.ID.String()+"/duplicate", token, fiber.Map{"copy_assignees": true}).StatusCode
+		}},
+		{"bulk-update", 2, func(t *testing.T, assigneeID uuid.UUID) int {
+			first := testutil.CreateTask(t, app.DB, project, "Bulk 1", nil)
+			second := testutil.CreateTask(t, app.DB, project, "Bulk 2", nil)
+			return app.Do(t, fiber.MethodPatch, projectTasksPath+"/bulk-update", token, fiber.Map{
+				"ids":         []uuid.UUID{first.ID, second.ID},
+				"assignee_id": assigneeID,
+			}).StatusCode
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notified := testutil.CreateUser(t, app.DB, tt.name+"@example.com")
+			noEmail := testutil.CreateUser(t, app.DB, tt.name+"-no-email@example.com")
+			if err := app.DB.Model(&noEmail).Update("email_notifications", false).Error; err != nil {
+				t.Fatal(err)
+			}
+			optedOut := testutil.CreateUser(t, app.DB, tt.name+"-opted-out@example.com")
+			pref := models.DefaultNotificationPreference(optedOut.ID)
+			pref.TaskAssigned = false
+			if err := app.DB.Create(&pref).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			for _, assignee := range []models.User{notified, noEmail, optedOut} {
+				if status := tt.assign(t, assignee.ID); status != fiber.StatusOK && status != fiber.StatusCreated {
+					t.Fatalf("assigning to %s: status %d", assignee.Email, status)
+				}
+			}
+
+			expectNotifications(t, app, notified.ID, tt.tasks)
+			expectNotifications(t, app, noEmail.ID, tt.tasks)
+			expectNotifications(t, app, optedOut.ID, 0)
+			if sent := app.Outbox.To(t, notified.Email, tt.tasks); len(sent) != tt.tasks {
+				t.Errorf("%d emails to the assignee, want %d", len(sent), tt.tasks)
+			}
+			if sent := app.Outbox.To(t, noEmail.Email, 0); len(sent) != 0 {
+				t.Errorf("%d emails to an assignee with email notifications off", len(sent))
+			}
+			if sent := app.Outbox.To(t, optedOut.Email, 0); len(sent) != 0 {
+				t.Errorf("%d emails to an assignee who opted out", len(sent))
+			}
+		})
+	}
+
+	// Assigning yourself notifies nobody
+	resp := app.Do(t, fiber.MethodPost, projectTasksPath, token, fiber.Map{"title": "Mine", "assignee_id": owner.ID})
+	testapp.ExpectStatus(t, resp, fiber.StatusCreated)
+	expectNotifications(t, app, owner.ID, 0)
+}
+
+func expectNotifications(t *testing.T, app *testapp.App, userID uuid.UUID, want int) {
+	t.Helper()
+
+	var count int64
+	if err := app.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ?", userID, models.NotificationTypeTaskAssigned).
+		Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != int64(want) {
+		t.Errorf("%d assignment notifications, want %d", count, want)
+	}
+}
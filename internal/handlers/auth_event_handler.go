@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecordAuthEvent adds an entry to the auth audit log when AUTH_EVENT_LOG is
+// on. A failed write is logged but never fails the request.
+func RecordAuthEvent(c *fiber.Ctx, db *gorm.DB, cfg *config.Config, eventType string, userID *uuid.UUID, email string) {
+	if !cfg.JWT.LogAuthEvents {
+		return
+	}
+
+	event := models.AuthEvent{
+		UserID:    userID,
+		Email:     email,
+		Type:      eventType,
+		IPAddress: c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+	if err := db.WithContext(c.UserContext()).Create(&event).Error; err != nil {
+		log.Printf("⚠️  Failed to record %s auth event for %s: %v", eventType, email, err)
+	}
+}
+
+type AuthEventHandler struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewAuthEventHandler(db *gorm.DB, cfg *config.Config) *AuthEventHandler {
+	return &AuthEventHandler{
+		db:  db,
+		cfg: cfg,
+	}
+}
+
+// GetAuthEvents lists auth audit log entries, newest first, optionally for
+// one user, of one type or within a time range. Admin only.
+func (h *AuthEventHandler) GetAuthEvents(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	admin, err := isAdmin(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify permissions",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !admin {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:     "Forbidden",
+			Message:   "Only admins can view auth events",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrAdminRequired,
+		})
+	}
+
+	var filter models.AuthEventFilterQuery
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid query parameters",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	var userID *uuid.UUID
+	if filter.UserID != "" {
+		parsed, err := uuid.Parse(filter.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "user_id must be a user ID",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidQuery,
+			})
+		}
+		userID = &parsed
+	}
+
+	var from, to *time.Time
+	for _, bound := range []struct {
+		name  string
+		value string
+		dest  **time.Time
+	}{
+		{"from", filter.From, &from},
+		{"to", filter.To, &to},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   bound.name + " must be an RFC 3339 timestamp",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidQuery,
+			})
+		}
+		*bound.dest = &t
+	}
+
+	matchingEvents := func(db *gorm.DB) *gorm.DB {
+		if userID != nil {
+			db = db.Where("user_id = ?", *userID)
+		}
+		if filter.Type != "" {
+			db = db.Where("type = ?", filter.Type)
+		}
+		if from != nil {
+			db = db.Where("created_at >= ?", *from)
+		}
+		if to != nil {
+			db = db.Where("created_at < ?", *to)
+		}
+		return db
+	}
+
+	// Parse pagination parameters
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultUserLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	var events []models.AuthEvent
+	var total int64
+
+	if err := db.Model(&models.AuthEvent{}).Scopes(matchingEvents).Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count auth events",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if !pastLastPage(offset, total) {
+		if err := db.Scopes(matchingEvents).
+			Order(stableOrder).
+			Offset(offset).Limit(limit).Find(&events).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch auth events",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	return c.JSON(models.ListResponse{
+		Data: events,
+		Pagination: models.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestAutoStartRecordsActivity(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	assignee := testutil.CreateUser(t, app.DB, "assignee@example.com")
+	token := app.Token(t, owner)
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Starting")
+	if err := app.DB.Model(&project).Update("auto_start_on_assign", true).Error; err != nil {
+		t.Fatal(err)
+	}
+	projectTasksPath := "/api/v1/projects/" + project.ID.String() + "/tasks"
+
+	assigns := map[string]func(taskID uuid.UUID) int{
+		"update": func(taskID uuid.UUID) int {
+			return app.Do(t, fiber.MethodPut, "/api/v1/tasks/"+taskID.String(), token, fiber.Map{"assignee_id": assignee.ID}).StatusCode
+		},
+		"assignee": func(taskID uuid.UUID) int {
+			return app.Do(t, fiber.MethodPatch, "/api/v1/tasks/"+taskID.String()+"/assignee", token, fiber.Map{"assignee_id": assignee.ID}).StatusCode
+		},
+		"bulk-assign": func(taskID uuid.UUID) int {
+			return app.Do(t, fiber.MethodPatch, projectTasksPath+"/bulk-assign", token, fiber.Map{
+				"task_ids":    []uuid.UUID{taskID},
+				"assignee_id": assignee.ID,
+			}).StatusCode
+		},
+		"bulk-update": func(taskID uuid.UUID) int {
+			return app.Do(t, fiber.MethodPatch, projectTasksPath+"/bulk-update", token, fiber.Map{
+				"ids":         []uuid.UUID{taskID},
+				"assignee_id": assignee.ID,
+			}).StatusCode
+		},
+	}
+	for name, assign := range assigns {
+		t.Run(name, func(t *testing.T) {
+			task := testutil.CreateTask(t, app.DB, project, name, nil)
+			if status := assign(task.ID); status != fiber.StatusOK {
+				t.Fatalf("status = %d, want %d", status, fiber.StatusOK)
+			}
+
+			var stored models.Task
+			if err := app.DB.First(&stored, task.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != models.TaskStatusInProgress {
+				t.Errorf("status = %s, want %s", stored.Status, models.TaskStatusInProgress)
+			}
+
+			var activities []models.TaskActivity
+			if err := app.DB.Where("task_id = ? AND action = ?", task.ID, models.TaskActivityAutoStarted).
+				Find(&activities).Error; err != nil {
+				t.Fatal(err)
+			}
+			if len(activities) != 1 {
+				t.Fatalf("%d auto_started activities, want 1", len(activities))
+			}
+			if actor := activities[0].ActorID; actor == nil || *actor != owner.ID {
+				t.Errorf("activity actor = %v, want %s", actor, owner.ID)
+			}
+
+			// Assigning the same user again changes nothing and records nothing
+			if status := assign(task.ID); status != fiber.StatusOK {
+				t.Fatalf("status = %d, want %d", status, fiber.StatusOK)
+			}
+			var count int64
+			if err := app.DB.Model(&models.TaskActivity{}).
+				Where("task_id = ? AND action = ?", task.ID, models.TaskActivityAutoStarted).
+				Count(&count).Error; err != nil {
+				t.Fatal(err)
+			}
+			if count != 1 {
+				t.Errorf("%d auto_started activities after reassigning, want 1", count)
+			}
+		})
+	}
+}
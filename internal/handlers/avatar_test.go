@@ -0,0 +1,65 @@
+package handlers_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestUploadAvatarDeletesPreviousFile(t *testing.T) {
+	app := testapp.New(t)
+	user := testutil.CreateUser(t, app.DB, "avatar@example.com")
+	token := app.Token(t, user)
+
+	upload := func() string {
+		t.Helper()
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("avatar", "avatar.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte("\x89PNG\r\n\x1a\n not really an image"))
+		form.Close()
+
+		req := httptest.NewRequest(fiber.MethodPost, "/api/v1/users/me/avatar", &body)
+		req.Header.Set(fiber.HeaderContentType, form.FormDataContentType())
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testapp.ExpectStatus(t, resp, fiber.StatusOK)
+
+		var uploaded testapp.Response[models.UserResponse]
+		testapp.Decode(t, resp, &uploaded)
+		if uploaded.Data.AvatarURL == nil {
+			t.Fatal("response has no avatar_url")
+		}
+		key := strings.TrimPrefix(*uploaded.Data.AvatarURL, app.Cfg.Storage.PublicURL)
+		return filepath.Join(app.Cfg.Storage.LocalDir, filepath.FromSlash(key))
+	}
+
+	first := upload()
+	second := upload()
+	if first == second {
+		t.Fatalf("both uploads were stored as %s", first)
+	}
+	if _, err := os.Stat(first); !os.IsNotExist(err) {
+		t.Errorf("previous avatar %s still exists (err %v)", first, err)
+	}
+	if _, err := os.Stat(second); err != nil {
+		t.Errorf("new avatar: %v", err)
+	}
+}
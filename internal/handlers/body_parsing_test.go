@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCreateUserParsesJSONAndForm(t *testing.T) {
+	app := testapp.New(t)
+
+	requests := map[string]func() *http.Response{
+		"json": func() *http.Response {
+			return app.Do(t, fiber.MethodPost, "/api/v1/auth/register", "", models.UserCreateRequest{
+				Email:     "json@example.com",
+				Password:  "secret123",
+				FirstName: "Jay",
+				LastName:  "Son",
+				Timezone:  "Europe/Berlin",
+			})
+		},
+		"form": func() *http.Response {
+			return app.DoForm(t, fiber.MethodPost, "/api/v1/auth/register", "", url.Values{
+				"email":      {"form@example.com"},
+				"password":   {"secret123"},
+				"first_name": {"Fo"},
+				"last_name":  {"Rm"},
+				"timezone":   {"Europe/Berlin"},
+			})
+		},
+	}
+	for name, send := range requests {
+		t.Run(name, func(t *testing.T) {
+			resp := send()
+			testapp.ExpectStatus(t, resp, fiber.StatusCreated)
+
+			var body testapp.Response[models.UserResponse]
+			testapp.Decode(t, resp, &body)
+			if body.Data.Email != name+"@example.com" {
+				t.Errorf("email = %q, want %q", body.Data.Email, name+"@example.com")
+			}
+			if body.Data.Timezone != "Europe/Berlin" {
+				t.Errorf("timezone = %q, want Europe/Berlin", body.Data.Timezone)
+			}
+		})
+	}
+}
+
+func TestCreateTaskParsesJSONAndForm(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Parsing")
+	token := app.Token(t, owner)
+	path := "/api/v1/projects/" + project.ID.String() + "/tasks"
+
+	high := models.TaskPriorityHigh
+	requests := map[string]func() *http.Response{
+		"json": func() *http.Response {
+			return app.Do(t, fiber.MethodPost, path, token, models.TaskCreateRequest{
+				Title:      "json",
+				AssigneeID: &owner.ID,
+				Priority:   &high,
+			})
+		},
+		"form": func() *http.Response {
+			return app.DoForm(t, fiber.MethodPost, path, token, url.Values{
+				"title":       {"form"},
+				"assignee_id": {owner.ID.String()},
+				"priority":    {string(high)},
+			})
+		},
+	}
+	for name, send := range requests {
+		t.Run(name, func(t *testing.T) {
+			resp := send()
+			testapp.ExpectStatus(t, resp, fiber.StatusCreated)
+
+			var body testapp.Response[models.TaskResponse]
+			testapp.Decode(t, resp, &body)
+			task := body.Data
+			if task.Title != name {
+				t.Errorf("title = %q, want %q", task.Title, name)
+			}
+			if task.AssigneeID == nil || *task.AssigneeID != owner.ID {
+				t.Errorf("assignee_id = %v, want %s", task.AssigneeID, owner.ID)
+			}
+			if task.Priority != high {
+				t.Errorf("priority = %q, want %q", task.Priority, high)
+			}
+		})
+	}
+}
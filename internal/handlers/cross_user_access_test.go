@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// TestCrossUserAccess checks that nothing of another user's can be read or
+// changed: their projects, tasks and API keys answer 404, and their profile
+// 403.
+func TestCrossUserAccess(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	intruder := testutil.CreateUser(t, app.DB, "intruder@example.com")
+	token := app.Token(t, intruder)
+
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Private")
+	task := testutil.CreateTask(t, app.DB, project, "Private task", &owner.ID)
+	ownProject := testutil.CreateProject(t, app.DB, intruder.ID, "Own")
+	ownTask := testutil.CreateTask(t, app.DB, ownProject, "Own task", nil)
+
+	apiKey := models.APIKey{UserID: owner.ID, Name: "CI", Prefix: "tf_owner", KeyHash: "owner-key-hash"}
+	if err := app.DB.Create(&apiKey).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	projectPath := "/api/v1/projects/" + project.ID.String()
+	taskPath := "/api/v1/tasks/" + task.ID.String()
+	projectTasksPath := projectPath + "/tasks"
+	userPath := "/api/v1/users/" + owner.ID.String()
+	later := time.Now().Add(time.Hour)
+	done := models.TaskStatusDone
+
+	tests := []struct {
+		method string
+		path   string
+		body   interface{}
+		want   int
+	}{
+		{fiber.MethodGet, projectPath, nil, fiber.StatusNotFound},
+		{fiber.MethodPut, projectPath, fiber.Map{"name": "Taken"}, fiber.StatusNotFound},
+		{fiber.MethodPatch, projectPath, fiber.Map{"name": "Taken"}, fiber.StatusNotFound},
+		{fiber.MethodGet, projectPath + "/trend", nil, fiber.StatusNotFound},
+		{fiber.MethodGet, projectPath + "/board", nil, fiber.StatusNotFound},
+		{fiber.MethodGet, projectPath + "/workload", nil, fiber.StatusNotFound},
+		{fiber.MethodGet, projectPath + "/permissions", nil, fiber.StatusNotFound},
+		{fiber.MethodGet, projectPath + "/export", nil, fiber.StatusNotFound},
+		{fiber.MethodGet, projectPath + "/assignees", nil, fiber.StatusNotFound},
+		{fiber.MethodPost, projectPath + "/duplicate", fiber.Map{}, fiber.StatusNotFound},
+		{fiber.MethodPost, projectPath + "/transfer", fiber.Map{"new_owner_id": intruder.ID}, fiber.StatusNotFound},
+		{fiber.MethodPost, projectPath + "/favorite", nil, fiber.StatusNotFound},
+		{fiber.MethodDelete, projectPath + "/favorite", nil, fiber.StatusNotFound},
+
+		{fiber.MethodGet, projectTasksPath, nil, fiber.StatusNotFound},
+		{fiber.MethodPost, projectTasksPath, fiber.Map{"title": "Sneaky"}, fiber.StatusNotFound},
+		{fiber.MethodGet, projectTasksPath + "/changes?since=" + time.Now().Add(-time.Hour).Format(time.RFC3339), nil, fiber.StatusNotFound},
+		{fiber.MethodPost, projectTasksPath + "/bulk", []fiber.Map{{"title": "Sneaky"}}, fiber.StatusNotFound},
+		{fiber.MethodPatch, projectTasksPath + "/bulk-assign", fiber.Map{"task_ids": []uuid.UUID{task.ID}, "assignee_id": intruder.ID}, fiber.StatusNotFound},
+		{fiber.MethodPatch, projectTasksPath + "/bulk-update", fiber.Map{"ids": []uuid.UUID{task.ID}, "status": done}, fiber.StatusNotFound},
+		{fiber.MethodPost, projectTasksPath + "/query", fiber.Map{}, fiber.StatusNotFound},
+
+		{fiber.MethodGet, taskPath, nil, fiber.StatusNotFound},
+		{fiber.MethodGet, taskPath + "/context", nil, fiber.StatusNotFound},
+		{fiber.MethodPut, taskPath, fiber.Map{"title": "Taken"}, fiber.StatusNotFound},
+		{fiber.MethodPatch, taskPath + "/status", fiber.Map{"status": done}, fiber.StatusNotFound},
+		{fiber.MethodPatch, taskPath + "/reopen", nil, fiber.StatusNotFound},
+		{fiber.MethodPatch, taskPath + "/assignee", fiber.Map{"assignee_id": intruder.ID}, fiber.StatusNotFound},
+		{fiber.MethodPatch, taskPath + "/snooze", fiber.Map{"snoozed_until": later}, fiber.StatusNotFound},
+		{fiber.MethodPatch, taskPath + "/archive", fiber.Map{}, fiber.StatusNotFound},
+		{fiber.MethodPost, taskPath + "/clone", fiber.Map{}, fiber.StatusNotFound},
+		{fiber.MethodPost, taskPath + "/merge", fiber.Map{"target_id": ownTask.ID}, fiber.StatusNotFound},
+		// Merging into someone else's task is as hidden as merging from it
+		{fiber.MethodPost, "/api/v1/tasks/" + ownTask.ID.String() + "/merge", fiber.Map{"target_id": task.ID}, fiber.StatusNotFound},
+		{fiber.MethodDelete, taskPath, nil, fiber.StatusNotFound},
+
+		{fiber.MethodDelete, "/api/v1/api-keys/" + apiKey.ID.String(), nil, fiber.StatusNotFound},
+
+		{fiber.MethodPut, userPath, fiber.Map{"first_name": "Taken"}, fiber.StatusForbidden},
+		{fiber.MethodDelete, userPath, nil, fiber.StatusForbidden},
+
+		// Deleting the project goes last so the requests above still find it
+		{fiber.MethodDelete, projectPath, nil, fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			resp := app.Do(t, tt.method, tt.path, token, tt.body)
+			testapp.ExpectStatus(t, resp, tt.want)
+		})
+	}
+
+	// None of the requests changed anything
+	var stored models.Task
+	if err := app.DB.First(&stored, task.ID).Error; err != nil {
+		t.Fatalf("owner's task is gone: %v", err)
+	}
+	if stored.Title != task.Title || stored.Status != task.Status || stored.AssigneeID == nil || *stored.AssigneeID != owner.ID {
+		t.Errorf("owner's task changed: %+v", stored)
+	}
+	var storedProject models.Project
+	if err := app.DB.First(&storedProject, project.ID).Error; err != nil {
+		t.Fatalf("owner's project is gone: %v", err)
+	}
+	if storedProject.Name != project.Name || storedProject.OwnerID != owner.ID {
+		t.Errorf("owner's project changed: %+v", storedProject)
+	}
+	var tasks int64
+	if err := app.DB.Model(&models.Task{}).Where("project_id = ?", project.ID).Count(&tasks).Error; err != nil {
+		t.Fatal(err)
+	}
+	if tasks != 1 {
+		t.Errorf("owner's project has %d tasks, want 1", tasks)
+	}
+	if err := app.DB.First(&models.APIKey{}, apiKey.ID).Error; err != nil {
+		t.Errorf("owner's API key is gone: %v", err)
+	}
+
+	// Lists only ever show the caller's own tasks
+	resp := app.Do(t, fiber.MethodGet, "/api/v1/users/"+owner.ID.String()+"/assigned-tasks", token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+	var assigned testapp.Response[[]models.TaskResponse]
+	testapp.Decode(t, resp, &assigned)
+	if len(assigned.Data) != 0 {
+		t.Errorf("assigned-tasks lists %d of the owner's tasks", len(assigned.Data))
+	}
+}
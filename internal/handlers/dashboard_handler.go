@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"time"
+
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// dueSoonDays is how many days after today a due date counts as "due soon"
+	dueSoonDays = 7
+
+	// recentTasksLimit is the number of recently updated tasks on the dashboard
+	recentTasksLimit = 5
+)
+
+type DashboardHandler struct {
+	db *gorm.DB
+}
+
+func NewDashboardHandler(db *gorm.DB) *DashboardHandler {
+	return &DashboardHandler{
+		db: db,
+	}
+}
+
+// GetDashboard returns project and task aggregates for the current user's home screen
+func (h *DashboardHandler) GetDashboard(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Count projects by status
+	var statusCounts []struct {
+		Status models.ProjectStatus
+		Count  int64
+	}
+	if err := db.Model(&models.Project{}).
+		Select("status, COUNT(*) AS count").
+		Where("owner_id = ?", currentUserID).
+		Group("status").
+		Scan(&statusCounts).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count projects",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	projectsByStatus := map[models.ProjectStatus]int64{
+		models.ProjectStatusActive:    0,
+		models.ProjectStatusArchived:  0,
+		models.ProjectStatusCompleted: 0,
+	}
+	for _, sc := range statusCounts {
+		projectsByStatus[sc.Status] = sc.Count
+	}
+
+	// Due dates are compared against day boundaries in the user's timezone
+	loc, err := userLocation(c, db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid timezone",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+	today := startOfDay(time.Now(), loc)
+	dueSoonEnd := today.AddDate(0, 0, dueSoonDays+1)
+
+	// Count tasks across the user's projects
+	var taskCounts models.DashboardTaskCounts
+	if err := db.Model(&models.Task{}).
+		Joins("JOIN projects ON tasks.project_id = projects.id AND projects.deleted_at IS NULL").
+		Select(
+			"COUNT(*) AS total, "+
+				"COUNT(*) FILTER (WHERE tasks.due_date < ? AND "+models.OpenStatusCondition+") AS overdue, "+
+				"COUNT(*) FILTER (WHERE tasks.due_date >= ? AND tasks.due_date < ? AND "+models.OpenStatusCondition+") AS due_soon",
+			today, today, dueSoonEnd,
+		).
+		Where("projects.owner_id = ?", currentUserID).
+		Scan(&taskCounts).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Get the most recently updated tasks
+	var recentTasks []models.Task
+	if err := db.Preload("Project").Preload("Assignee").Preload("Creator").
+		Where("tasks.project_id IN (?)", ownedProjectIDs(db, currentUserID)).
+		Order("tasks.updated_at DESC").
+		Limit(recentTasksLimit).
+		Find(&recentTasks).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch recent tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Convert to response format
+	taskResponses := make([]models.TaskResponse, len(recentTasks))
+	for i, task := range recentTasks {
+		taskResponses[i] = task.ToResponse()
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Dashboard retrieved successfully",
+		Data: models.DashboardResponse{
+			ProjectsByStatus: projectsByStatus,
+			Tasks:            taskCounts,
+			RecentTasks:      taskResponses,
+		},
+	})
+}
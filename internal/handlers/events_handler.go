@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// keepAliveInterval is how often an idle event stream sends a comment so
+// proxies and clients keep the connection open
+const keepAliveInterval = 15 * time.Second
+
+type EventsHandler struct {
+	db     *gorm.DB
+	broker *events.Broker
+}
+
+func NewEventsHandler(db *gorm.DB, broker *events.Broker) *EventsHandler {
+	return &EventsHandler{
+		db:     db,
+		broker: broker,
+	}
+}
+
+// StreamProjectEvents streams task changes in a project as server-sent events
+func (h *EventsHandler) StreamProjectEvents(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Verify project exists and user owns it
+	var project models.Project
+	if err := db.Where("id = ? AND owner_id = ?", projectID, currentUserID).
+		First(&project).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	stream, unsubscribe := h.broker.Subscribe(project.ID)
+	conn := c.Context().Conn()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		ticker := time.NewTicker(keepAliveInterval)
+		defer ticker.Stop()
+
+		// The server write timeout applies to the whole response, so push the
+		// deadline forward before every write to keep the stream open
+		write := func(format string, args ...interface{}) error {
+			conn.SetWriteDeadline(time.Now().Add(2 * keepAliveInterval))
+			fmt.Fprintf(w, format, args...)
+			return w.Flush()
+		}
+
+		if err := write("retry: %d\n\n", 3000); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-stream:
+				if !ok {
+					// Dropped for falling behind; the client reconnects
+					return
+				}
+				payload, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				if err := write("event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+					return
+				}
+
+				// Ownership was only checked on connecting; end the stream
+				// once the user no longer owns the project
+				if event.IsOwnershipChange() {
+					if owned, err := ownsProject(primary(h.db), currentUserID, project.ID); err != nil || !owned {
+						return
+					}
+				}
+			case <-ticker.C:
+				if err := write(": keep-alive\n\n"); err != nil {
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type FeatureHandler struct {
+	db  *gorm.DB
+	cfg *config.Config
+}
+
+func NewFeatureHandler(db *gorm.DB, cfg *config.Config) *FeatureHandler {
+	return &FeatureHandler{
+		db:  db,
+		cfg: cfg,
+	}
+}
+
+// GetFeatures lists the feature flags in effect and whether each is on. Admin only.
+func (h *FeatureHandler) GetFeatures(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	admin, err := isAdmin(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify permissions",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !admin {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:     "Forbidden",
+			Message:   "Only admins can view feature flags",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrAdminRequired,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Feature flags retrieved successfully",
+		Data:    h.cfg.Features,
+	})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// taskResponseFields are the names the fields query parameter accepts for tasks
+var taskResponseFields = jsonFieldNames(models.TaskResponse{})
+
+// jsonFieldNames lists the JSON names of a struct's fields in declaration order
+func jsonFieldNames(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseFields reads the comma-separated fields query parameter. It returns nil
+// when the parameter is missing, meaning every field is included.
+func parseFields(c *fiber.Ctx, known []string) ([]string, error) {
+	raw := c.Query("fields")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(known, field) {
+			return nil, fmt.Errorf("fields must be a comma-separated list of %s", strings.Join(known, ", "))
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("fields must name at least one field")
+	}
+	return fields, nil
+}
+
+// selectFields returns v with only the given JSON fields, or v itself when
+// fields is nil. Fields v omits when empty stay omitted.
+func selectFields(v interface{}, fields []string) (interface{}, error) {
+	if fields == nil {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected, nil
+}
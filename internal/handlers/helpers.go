@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/mailer"
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// stableOrder sorts list results newest first with the ID as a tie-breaker so
+// pages never overlap or skip rows that share a timestamp
+const stableOrder = "created_at DESC, id DESC"
+
+// parsePagination reads the page and limit query parameters, using
+// defaultLimit when limit is missing or out of range. Pages beyond the
+// configured maximum are rejected so a huge page cannot force a slow scan.
+func parsePagination(c *fiber.Ctx, cfg config.PaginationConfig, defaultLimit int) (page, limit, offset int, err error) {
+	page, _ = strconv.Atoi(c.Query("page", "1"))
+	limit, _ = strconv.Atoi(c.Query("limit", strconv.Itoa(defaultLimit)))
+
+	if page < 1 {
+		page = 1
+	}
+	if page > cfg.MaxPage {
+		return 0, 0, 0, fmt.Errorf("page must be at most %d", cfg.MaxPage)
+	}
+	if limit < 1 || limit > config.MaxPageLimit {
+		limit = defaultLimit
+	}
+
+	return page, limit, (page - 1) * limit, nil
+}
+
+// pastLastPage reports whether offset skips all total rows, in which case the
+// page is empty and lists skip the query
+func pastLastPage(offset int, total int64) bool {
+	return int64(offset) >= total
+}
+
+// Columns each list endpoint can be sorted by
+var (
+	userSortFields    = []string{"created_at", "updated_at", "email", "first_name", "last_name"}
+	projectSortFields = []string{"created_at", "updated_at", "name", "status"}
+	taskSortFields    = []string{"created_at", "updated_at", "title", "status", "priority", "due_date"}
+)
+
+// parseSort builds a list's ORDER BY from the sort and order query parameters,
+// falling back to the configured default. The ID breaks ties so pages never
+// overlap or skip rows that share a value.
+func parseSort(c *fiber.Ctx, cfg config.PaginationConfig, fields []string) (string, error) {
+	field := c.Query("sort", cfg.DefaultSortField)
+	if !slices.Contains(fields, field) {
+		return "", fmt.Errorf("sort must be one of %s", strings.Join(fields, ", "))
+	}
+
+	direction := strings.ToLower(c.Query("order", cfg.DefaultSortDirection))
+	if direction != config.SortAsc && direction != config.SortDesc {
+		return "", fmt.Errorf("order must be %s or %s", config.SortAsc, config.SortDesc)
+	}
+
+	return fmt.Sprintf("%s %s NULLS LAST, id %s", field, direction, direction), nil
+}
+
+// primary forces queries onto the primary database so reads that follow a
+// write see its result even when a read replica is configured
+func primary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write)
+}
+
+// ownedProjectIDs is a subquery selecting the IDs of the user's projects.
+// Resources outside it are reported as not found rather than forbidden so
+// their existence is not leaked to other users.
+func ownedProjectIDs(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Model(&models.Project{}).Select("id").Where("owner_id = ?", userID)
+}
+
+// notificationPreference returns the user's notification preferences, or the
+// defaults when the user never changed them
+func notificationPreference(db *gorm.DB, userID uuid.UUID) (models.NotificationPreference, error) {
+	var pref models.NotificationPreference
+	if err := db.Where("user_id = ?", userID).First(&pref).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return models.DefaultNotificationPreference(userID), nil
+		}
+		return pref, err
+	}
+	return pref, nil
+}
+
+// notifyAssigned tells the new assignees of tasks whose assignee just changed:
+// it writes their in-app notifications with tx and returns a function that
+// queues the assignment emails on mail, to be called once tx has committed.
+// Unassigned tasks, tasks the caller assigned to themselves and assignees who
+// turned the notification off are skipped; the email also respects the
+// assignee's email_notifications setting.
+func notifyAssigned(tx *gorm.DB, mail *mailer.Queue, actorID uuid.UUID, tasks []models.Task) (func(), error) {
+	// A nil entry is an assignee who opted out
+	assignees := map[uuid.UUID]*models.User{}
+	projects := map[uuid.UUID]*models.Project{}
+	var notifications []models.Notification
+	var emails []mailer.Message
+
+	for i := range tasks {
+		task := tasks[i]
+		if task.AssigneeID == nil || *task.AssigneeID == actorID {
+			continue
+		}
+
+		assignee, seen := assignees[*task.AssigneeID]
+		if !seen {
+			pref, err := notificationPreference(tx, *task.AssigneeID)
+			if err != nil {
+				return nil, err
+			}
+			if pref.Allows(models.NotificationTypeTaskAssigned) {
+				assignee = &models.User{}
+				if err := tx.First(assignee, *task.AssigneeID).Error; err != nil {
+					return nil, err
+				}
+			}
+			assignees[*task.AssigneeID] = assignee
+		}
+		if assignee == nil {
+			continue
+		}
+
+		notifications = append(notifications, models.NewTaskAssignedNotification(&task, assignee.ID))
+		if !assignee.EmailNotifications {
+			continue
+		}
+
+		project, ok := projects[task.ProjectID]
+		if !ok {
+			project = &models.Project{}
+			if err := tx.First(project, task.ProjectID).Error; err != nil {
+				return nil, err
+			}
+			projects[task.ProjectID] = project
+		}
+		task.Project = *project
+		if msg, err := mailer.TaskAssignedEmail(assignee, &task); err == nil {
+			emails = append(emails, msg)
+		}
+	}
+
+	if len(notifications) > 0 {
+		if err := tx.Create(&notifications).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return func() {
+		for _, msg := range emails {
+			mail.Enqueue(msg)
+		}
+	}, nil
+}
+
+// recordTaskActivity adds an entry to a task's activity log. Callers pass the
+// transaction that makes the change so the two commit together. When the
+// request was made with an impersonation token, the admin's ID is added to the
+// payload as impersonated_by.
+func recordTaskActivity(c *fiber.Ctx, tx *gorm.DB, taskID uuid.UUID, actorID *uuid.UUID, action string, payload map[string]interface{}) error {
+	if adminID, ok := middleware.GetImpersonatorIDFromContext(c); ok {
+		payload["impersonated_by"] = adminID
+	}
+	activity, err := models.NewTaskActivity(taskID, actorID, action, payload)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&activity).Error
+}
+
+// userLocation resolves the timezone used for day boundaries: the tz query
+// parameter when given, otherwise the user's saved timezone
+func userLocation(c *fiber.Ctx, db *gorm.DB, userID uuid.UUID) (*time.Location, error) {
+	name := c.Query("tz")
+	if name == "" {
+		var user models.User
+		if err := db.Select("timezone").First(&user, userID).Error; err != nil {
+			return nil, err
+		}
+		name = user.Timezone
+	}
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// startOfDay returns midnight of t's day in loc
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// isUniqueViolation reports whether err comes from a unique index rejecting a
+// row, such as a concurrent request inserting the same value first
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// isAdmin reports whether the given user has admin rights
+func isAdmin(db *gorm.DB, userID uuid.UUID) (bool, error) {
+	var user models.User
+	if err := db.Select("is_admin").First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.IsAdmin, nil
+}
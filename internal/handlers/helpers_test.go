@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"testing"
+
+	"taskflow-api/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// assertAssignee fails the test unless the task, deleted or not, has the
+// wanted assignee
+func assertAssignee(t *testing.T, db *gorm.DB, taskID uuid.UUID, want *uuid.UUID) {
+	t.Helper()
+
+	var task models.Task
+	if err := db.Unscoped().First(&task, taskID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !sameAssignee(task.AssigneeID, want) {
+		t.Errorf("task %q assignee = %v, want %v", task.Title, task.AssigneeID, want)
+	}
+}
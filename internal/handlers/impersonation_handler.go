@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"time"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/validation"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ImpersonationHandler struct {
+	db       *gorm.DB
+	cfg      *config.Config
+	validate *validator.Validate
+}
+
+func NewImpersonationHandler(db *gorm.DB, cfg *config.Config, values *validation.TaskValues) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		db:       db,
+		cfg:      cfg,
+		validate: validation.New(cfg, values),
+	}
+}
+
+// IssueUserToken gives an admin a short-lived token for another user so
+// support can reproduce what the user sees. Every token issued is recorded
+// in the impersonation log. Admin only; other admins cannot be impersonated.
+func (h *ImpersonationHandler) IssueUserToken(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid user ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	admin, err := isAdmin(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify permissions",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !admin {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:     "Forbidden",
+			Message:   "Only admins can impersonate users",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrAdminRequired,
+		})
+	}
+
+	var req models.ImpersonationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "Invalid request body",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidRequestBody,
+			})
+		}
+		if err := h.validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Validation Error",
+				Message:   err.Error(),
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrValidation,
+			})
+		}
+	}
+
+	var user models.User
+	if err := db.First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "User not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrUserNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Tokens never grant admin rights, so admins (the caller included) are off limits
+	if user.IsAdmin {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:     "Forbidden",
+			Message:   "Admins cannot be impersonated",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrImpersonationNotAllowed,
+		})
+	}
+	if !user.IsActive {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   "User is deactivated",
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrUserInactive,
+		})
+	}
+
+	token, expiresAt, err := middleware.GenerateImpersonationJWT(&user, currentUserID, h.cfg)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to generate token",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// The token is only handed out once the log entry is stored
+	entry := models.ImpersonationLog{
+		AdminID:   currentUserID,
+		UserID:    user.ID,
+		IPAddress: c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		ExpiresAt: expiresAt,
+	}
+	if req.Reason != "" {
+		entry.Reason = &req.Reason
+	}
+	if err := db.Create(&entry).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to record impersonation",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
+		Message: "Impersonation token issued successfully",
+		Data: models.ImpersonationTokenResponse{
+			Token:     token,
+			ExpiresAt: expiresAt,
+			ExpiresIn: int64(time.Until(expiresAt).Seconds()),
+			User:      user.ToResponse(),
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestMergeTask(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	assignee := testutil.CreateUser(t, app.DB, "assignee@example.com")
+	token := app.Token(t, owner)
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Merging")
+	source := testutil.CreateTask(t, app.DB, project, "Duplicate", nil)
+	target := testutil.CreateTask(t, app.DB, project, "Original", nil)
+
+	notification := models.NewTaskAssignedNotification(&source, assignee.ID)
+	if err := app.DB.Create(&notification).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	resp := app.Do(t, fiber.MethodPost, "/api/v1/tasks/"+source.ID.String()+"/merge", token, fiber.Map{"target_id": target.ID})
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+
+	if err := app.DB.First(&models.Task{}, source.ID).Error; err == nil {
+		t.Error("the merged task was not deleted")
+	}
+	if err := app.DB.First(&notification, notification.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if notification.TaskID == nil || *notification.TaskID != target.ID {
+		t.Errorf("notification points at %v, want the target %s", notification.TaskID, target.ID)
+	}
+
+	var activity models.TaskActivity
+	if err := app.DB.Where("task_id = ? AND action = ?", target.ID, models.TaskActivityMerged).
+		First(&activity).Error; err != nil {
+		t.Fatalf("no merged activity on the target: %v", err)
+	}
+	if activity.ActorID == nil || *activity.ActorID != owner.ID {
+		t.Errorf("activity actor = %v, want %s", activity.ActorID, owner.ID)
+	}
+	var payload struct {
+		SourceID uuid.UUID `json:"source_id"`
+	}
+	if err := json.Unmarshal(activity.Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.SourceID != source.ID {
+		t.Errorf("payload source_id = %s, want %s", payload.SourceID, source.ID)
+	}
+}
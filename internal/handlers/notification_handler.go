@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationHandler struct {
+	db *gorm.DB
+}
+
+func NewNotificationHandler(db *gorm.DB) *NotificationHandler {
+	return &NotificationHandler{
+		db: db,
+	}
+}
+
+// GetUnreadCount returns how many unread notifications the current user has.
+// It is a single indexed COUNT so clients can poll it for a badge.
+func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var count int64
+	if err := db.Model(&models.Notification{}).
+		Where("user_id = ? AND read = ?", currentUserID, false).
+		Count(&count).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count notifications",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Unread notification count retrieved successfully",
+		Data:    models.UnreadCountResponse{Count: count},
+	})
+}
+
+// MarkAllRead marks every unread notification of the current user as read
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	result := db.Model(&models.Notification{}).
+		Where("user_id = ? AND read = ?", currentUserID, false).
+		Update("read", true)
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to mark notifications as read",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Notifications marked as read",
+		Data:    models.MarkAllReadResponse{Updated: result.RowsAffected},
+	})
+}
+
+// GetPreferences returns which events notify the current user
+func (h *NotificationHandler) GetPreferences(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	pref, err := notificationPreference(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch notification preferences",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Notification preferences retrieved successfully",
+		Data:    pref.ToResponse(),
+	})
+}
+
+// UpdatePreferences changes which events notify the current user. Omitted
+// fields keep their current value.
+func (h *NotificationHandler) UpdatePreferences(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.NotificationPreferenceUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	pref, err := notificationPreference(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch notification preferences",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Update fields
+	if req.TaskAssigned != nil {
+		pref.TaskAssigned = *req.TaskAssigned
+	}
+	if req.TaskOverdue != nil {
+		pref.TaskOverdue = *req.TaskOverdue
+	}
+	if req.ProjectTransferred != nil {
+		pref.ProjectTransferred = *req.ProjectTransferred
+	}
+
+	// Users on the defaults have no row yet
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"task_assigned", "task_overdue", "project_transferred", "updated_at"}),
+	}).Create(&pref).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to update notification preferences",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Notification preferences updated successfully",
+		Data:    pref.ToResponse(),
+	})
+}
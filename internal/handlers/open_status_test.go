@@ -0,0 +1,59 @@
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCustomStatusesCountAsOpenUnlessClosed(t *testing.T) {
+	app := testapp.New(t)
+	admin := testutil.CreateAdmin(t, app.DB, "admin@example.com")
+	token := app.Token(t, admin)
+	project := testutil.CreateProject(t, app.DB, admin.ID, "Statuses")
+
+	for _, status := range []fiber.Map{{"name": "blocked"}, {"name": "shelved", "closed": true}} {
+		resp := app.Do(t, fiber.MethodPost, "/api/v1/task-statuses", token, status)
+		testapp.ExpectStatus(t, resp, fiber.StatusCreated)
+	}
+
+	// Only the todo and blocked tasks are open
+	for _, status := range []string{"todo", "blocked", "shelved", "done"} {
+		task := testutil.CreateTask(t, app.DB, project, status, &admin.ID)
+		if err := app.DB.Model(&task).Updates(map[string]interface{}{
+			"status":   status,
+			"due_date": time.Now().AddDate(0, 0, -2),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp := app.Do(t, fiber.MethodGet, "/api/v1/dashboard", token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+	var dashboard testapp.Response[models.DashboardResponse]
+	testapp.Decode(t, resp, &dashboard)
+	if dashboard.Data.Tasks.Overdue != 2 {
+		t.Errorf("dashboard overdue = %d, want 2", dashboard.Data.Tasks.Overdue)
+	}
+
+	resp = app.Do(t, fiber.MethodGet, "/api/v1/users/me/summary", token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+	var summary testapp.Response[models.UserSummaryResponse]
+	testapp.Decode(t, resp, &summary)
+	if summary.Data.AssignedTasks.Overdue != 2 {
+		t.Errorf("summary overdue = %d, want 2", summary.Data.AssignedTasks.Overdue)
+	}
+
+	var open int64
+	if err := app.DB.Model(&models.Task{}).Scopes(models.OpenStatusScope).Count(&open).Error; err != nil {
+		t.Fatal(err)
+	}
+	if open != 2 {
+		t.Errorf("%d open tasks, want 2", open)
+	}
+}
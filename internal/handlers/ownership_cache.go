@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"taskflow-api/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OwnershipCache remembers for a short time whether a user owns a project, so
+// hot read-only task endpoints can skip the ownership query. The project handler
+// invalidates a project's entries when it is updated, deleted or transferred.
+// Other instances only see those changes once their entries expire, so the
+// TTL bounds how long a transferred project may still be served to its
+// previous owner there. A zero TTL disables the cache.
+type OwnershipCache struct {
+	ttl time.Duration
+
+	mu          sync.Mutex
+	entries     map[uuid.UUID]map[uuid.UUID]ownershipEntry // project ID → user ID
+	invalidated map[uuid.UUID]time.Time                    // project ID → last invalidation
+	lastSweep   time.Time
+}
+
+type ownershipEntry struct {
+	owned     bool
+	expiresAt time.Time
+}
+
+func NewOwnershipCache(ttl time.Duration) *OwnershipCache {
+	return &OwnershipCache{
+		ttl:         ttl,
+		entries:     make(map[uuid.UUID]map[uuid.UUID]ownershipEntry),
+		invalidated: make(map[uuid.UUID]time.Time),
+		lastSweep:   time.Now(),
+	}
+}
+
+// Owns reports whether the user owns the (not deleted) project. On a cache miss
+// it queries the primary database rather than a replica, so an answer cached
+// right after InvalidateProject already reflects the committed change.
+func (c *OwnershipCache) Owns(db *gorm.DB, userID, projectID uuid.UUID) (bool, error) {
+	if owned, ok := c.get(userID, projectID); ok {
+		return owned, nil
+	}
+
+	queriedAt := time.Now()
+	owned, err := ownsProject(primary(db), userID, projectID)
+	if err != nil {
+		return false, err
+	}
+	c.set(userID, projectID, owned, queriedAt)
+	return owned, nil
+}
+
+// InvalidateProject forgets every cached answer for the project. Call it
+// after the change is committed.
+func (c *OwnershipCache) InvalidateProject(projectID uuid.UUID) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, projectID)
+	c.invalidated[projectID] = time.Now()
+}
+
+func (c *OwnershipCache) get(userID, projectID uuid.UUID) (bool, bool) {
+	if c.ttl <= 0 {
+		return false, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[projectID][userID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return false, false
+	}
+	return entry.owned, true
+}
+
+// set caches an answer unless the project was invalidated after it was
+// queried, which would make it stale. Answers older than the TTL are dropped
+// too, so invalidations only need to be remembered that long.
+func (c *OwnershipCache) set(userID, projectID uuid.UUID, owned bool, queriedAt time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Sub(queriedAt) > c.ttl || !c.invalidated[projectID].Before(queriedAt) {
+		return
+	}
+
+	// Drop expired entries about once per TTL so the map stays small
+	if now.Sub(c.lastSweep) > c.ttl {
+		for id, users := range c.entries {
+			for user, entry := range users {
+				if now.After(entry.expiresAt) {
+					delete(users, user)
+				}
+			}
+			if len(users) == 0 {
+				delete(c.entries, id)
+			}
+		}
+		for id, at := range c.invalidated {
+			if now.Sub(at) > c.ttl {
+				delete(c.invalidated, id)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	users, ok := c.entries[projectID]
+	if !ok {
+		users = make(map[uuid.UUID]ownershipEntry)
+		c.entries[projectID] = users
+	}
+	users[userID] = ownershipEntry{owned: owned, expiresAt: now.Add(c.ttl)}
+}
+
+// ownsProject reports whether the user owns the project. With an unscoped db
+// soft-deleted projects count too.
+func ownsProject(db *gorm.DB, userID, projectID uuid.UUID) (bool, error) {
+	var count int64
+	if err := db.Model(&models.Project{}).
+		Where("id = ? AND owner_id = ?", projectID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// projectExists reports whether the project exists, whoever owns it. With an
+// unscoped db soft-deleted projects count too.
+func projectExists(db *gorm.DB, projectID uuid.UUID) (bool, error) {
+	var count int64
+	if err := db.Model(&models.Project{}).Where("id = ?", projectID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,73 @@
+package handlers_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestPagingYieldsEachRowOnce(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	token := app.Token(t, owner)
+
+	users := map[uuid.UUID]bool{owner.ID: true}
+	projects := map[uuid.UUID]bool{}
+	tasks := map[uuid.UUID]bool{}
+	taskProject := testutil.CreateProject(t, app.DB, owner.ID, "Tasks")
+	projects[taskProject.ID] = true
+	for i := 0; i < 6; i++ {
+		users[testutil.CreateUser(t, app.DB, fmt.Sprintf("user%d@example.com", i)).ID] = true
+		projects[testutil.CreateProject(t, app.DB, owner.ID, fmt.Sprintf("Project %d", i)).ID] = true
+		tasks[testutil.CreateTask(t, app.DB, taskProject, fmt.Sprintf("Task %d", i), nil).ID] = true
+	}
+
+	// Identical timestamps leave only the tie-breaker to keep pages apart
+	createdAt := time.Now().Add(-time.Hour)
+	for _, table := range []string{"users", "projects", "tasks"} {
+		if err := app.DB.Exec("UPDATE "+table+" SET created_at = ?", createdAt).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lists := map[string]map[uuid.UUID]bool{
+		"/api/v1/users":    users,
+		"/api/v1/projects": projects,
+		"/api/v1/projects/" + taskProject.ID.String() + "/tasks": tasks,
+	}
+	for path, want := range lists {
+		t.Run(path, func(t *testing.T) {
+			seen := map[uuid.UUID]int{}
+			for page := 1; ; page++ {
+				resp := app.Do(t, fiber.MethodGet, path+"?limit=4&page="+strconv.Itoa(page), token, nil)
+				testapp.ExpectStatus(t, resp, fiber.StatusOK)
+				var body testapp.Response[[]struct {
+					ID uuid.UUID `json:"id"`
+				}]
+				testapp.Decode(t, resp, &body)
+				for _, row := range body.Data {
+					seen[row.ID]++
+				}
+				if page >= body.Pagination.TotalPages {
+					break
+				}
+			}
+
+			for id := range want {
+				if seen[id] != 1 {
+					t.Errorf("%s listed %d times", id, seen[id])
+				}
+			}
+			if len(seen) != len(want) {
+				t.Errorf("listed %d rows, want %d", len(seen), len(want))
+			}
+		})
+	}
+}
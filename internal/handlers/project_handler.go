@@ -1,47 +1,121 @@
 package handlers
 
 import (
+	"fmt"
 	"math"
 	"strconv"
+	"time"
 
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/mailer"
 	"taskflow-api/internal/middleware"
 	"taskflow-api/internal/models"
+	"taskflow-api/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// defaultTrendDays is the trend window used when days is not given
+	defaultTrendDays = 30
+
+	// maxTrendDays caps the trend window to keep responses small
+	maxTrendDays = 365
 )
 
 type ProjectHandler struct {
-	db       *gorm.DB
-	validate *validator.Validate
+	db        *gorm.DB
+	cfg       *config.Config
+	ownership *OwnershipCache
+	broker    *events.Broker
+	mail      *mailer.Queue
+	validate  *validator.Validate
 }
 
-func NewProjectHandler(db *gorm.DB) *ProjectHandler {
+func NewProjectHandler(db *gorm.DB, cfg *config.Config, values *validation.TaskValues, ownership *OwnershipCache, broker *events.Broker, mail *mailer.Queue) *ProjectHandler {
 	return &ProjectHandler{
-		db:       db,
-		validate: validator.New(),
+		db:        db,
+		cfg:       cfg,
+		ownership: ownership,
+		broker:    broker,
+		mail:      mail,
+		validate:  validation.New(cfg, values),
+	}
+}
+
+// favoriteProjectIDs reports which of the given projects the user has favorited
+func favoriteProjectIDs(db *gorm.DB, userID uuid.UUID, projectIDs ...uuid.UUID) (map[uuid.UUID]bool, error) {
+	favorited := make(map[uuid.UUID]bool)
+	if len(projectIDs) == 0 {
+		return favorited, nil
+	}
+
+	var ids []uuid.UUID
+	if err := db.Model(&models.ProjectFavorite{}).
+		Where("user_id = ? AND project_id IN ?", userID, projectIDs).
+		Pluck("project_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		favorited[id] = true
+	}
+	return favorited, nil
+}
+
+// checkProjectQuota reports whether the user already owns as many projects as
+// their quota allows. A per-user quota overrides the configured default, and
+// soft-deleted projects never count.
+func (h *ProjectHandler) checkProjectQuota(db *gorm.DB, userID uuid.UUID) (models.ProjectQuotaDetails, bool, error) {
+	details := models.ProjectQuotaDetails{Limit: h.cfg.Projects.MaxPerUser}
+
+	var user models.User
+	if err := db.Select("id", "project_quota").First(&user, userID).Error; err != nil {
+		return details, false, err
+	}
+	if user.ProjectQuota != nil {
+		details.Limit = *user.ProjectQuota
+	}
+	if details.Limit <= 0 {
+		return details, false, nil
+	}
+
+	query := db.Model(&models.Project{}).Where("owner_id = ?", userID)
+	if !h.cfg.Projects.QuotaCountsArchived {
+		query = query.Where("status <> ?", models.ProjectStatusArchived)
+	}
+	if err := query.Count(&details.Current).Error; err != nil {
+		return details, false, err
 	}
+
+	return details, details.Current >= int64(details.Limit), nil
 }
 
 // CreateProject creates a new project
 func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	var req models.ProjectCreateRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
 		})
 	}
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Validation Error",
-			Message: err.Error(),
-			Code:    fiber.StatusBadRequest,
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
 		})
 	}
 
@@ -49,17 +123,41 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Enforce the per-user project quota
+	quotaDetails, exceeded, err := h.checkProjectQuota(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count projects",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if exceeded {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   fmt.Sprintf("You can own at most %d projects", quotaDetails.Limit),
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrProjectQuota,
+			Details:   quotaDetails,
 		})
 	}
 
 	// Create project
 	project := models.Project{
-		Name:    req.Name,
-		OwnerID: currentUserID,
-		Status:  models.ProjectStatusActive,
+		Name:                req.Name,
+		OwnerID:             currentUserID,
+		Status:              models.ProjectStatusActive,
+		DefaultTaskPriority: models.TaskPriorityMedium,
+		AutoEscalateOverdue: req.AutoEscalateOverdue,
+		AutoStartOnAssign:   req.AutoStartOnAssign,
 	}
 
 	if req.Description != "" {
@@ -70,20 +168,26 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 		project.Color = req.Color
 	}
 
-	if err := h.db.Create(&project).Error; err != nil {
+	if req.DefaultTaskPriority != nil {
+		project.DefaultTaskPriority = *req.DefaultTaskPriority
+	}
+
+	if err := db.Create(&project).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to create project",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to create project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
 	// Load the project with owner
-	if err := h.db.Preload("Owner").First(&project, project.ID).Error; err != nil {
+	if err := primary(db).Preload("Owner").First(&project, project.ID).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to load project details",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to load project details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
@@ -95,56 +199,165 @@ func (h *ProjectHandler) CreateProject(c *fiber.Ctx) error {
 
 // GetProjects retrieves user's projects with pagination
 func (h *ProjectHandler) GetProjects(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	// Get current user
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
+	// Soft-deleted records are only visible to admins
+	query := db
+	if c.QueryBool("include_deleted") {
+		admin, err := isAdmin(db, currentUserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify permissions",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+		if !admin {
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+				Error:     "Forbidden",
+				Message:   "Only admins can include deleted records",
+				Code:      fiber.StatusForbidden,
+				ErrorCode: models.ErrAdminRequired,
+			})
+		}
+		query = db.Unscoped()
+	}
+
+	// Admins may list another user's projects with owner_id
+	ownerID := currentUserID
+	if param := c.Query("owner_id"); param != "" {
+		parsed, err := uuid.Parse(param)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "owner_id must be a user ID",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidQuery,
+			})
+		}
+		if parsed != currentUserID {
+			admin, err := isAdmin(db, currentUserID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+					Error:     "Internal Server Error",
+					Message:   "Failed to verify permissions",
+					Code:      fiber.StatusInternalServerError,
+					ErrorCode: models.ErrInternal,
+				})
+			}
+			if !admin {
+				return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+					Error:     "Forbidden",
+					Message:   "Only admins can list other users' projects",
+					Code:      fiber.StatusForbidden,
+					ErrorCode: models.ErrAdminRequired,
+				})
+			}
+		}
+		ownerID = parsed
+	}
+
 	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultProjectLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
 
-	if page < 1 {
-		page = 1
+	orderBy, err := parseSort(c, h.cfg.Pagination, projectSortFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+
+	// Favorites are the current user's, even when listing another owner
+	favorites := db.Model(&models.ProjectFavorite{}).Select("project_id").Where("user_id = ?", currentUserID)
+	favoritesOnly := c.QueryBool("favorites_only")
+	ownerProjects := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("owner_id = ?", ownerID)
+		if favoritesOnly {
+			db = db.Where("id IN (?)", favorites)
+		}
+		return db
 	}
 
-	offset := (page - 1) * limit
+	// favorites_first pins favorites ahead of the requested sort
+	order := clause.OrderBy{Expression: clause.Expr{SQL: orderBy}}
+	if c.QueryBool("favorites_first") {
+		order.Expression = clause.Expr{
+			SQL:                "id IN (?) DESC, " + orderBy,
+			Vars:               []interface{}{favorites},
+			WithoutParentheses: true,
+		}
+	}
 
 	var projects []models.Project
 	var total int64
 
-	// Count total projects for the user
-	if err := h.db.Model(&models.Project{}).Where("owner_id = ?", currentUserID).Count(&total).Error; err != nil {
+	// Count total projects for the owner
+	if err := query.Model(&models.Project{}).Scopes(ownerProjects).Count(&total).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to count projects",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to count projects",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
 	// Get projects with pagination
-	if err := h.db.Preload("Owner").Preload("Tasks").
-		Where("owner_id = ?", currentUserID).
-		Offset(offset).Limit(limit).Find(&projects).Error; err != nil {
+	if !pastLastPage(offset, total) {
+		if err := query.Preload("Owner").Preload("Tasks").
+			Scopes(ownerProjects).
+			Order(order).
+			Offset(offset).Limit(limit).Find(&projects).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch projects",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Convert to response format
+	projectIDs := make([]uuid.UUID, len(projects))
+	for i, project := range projects {
+		projectIDs[i] = project.ID
+	}
+	favorited, err := favoriteProjectIDs(db, currentUserID, projectIDs...)
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch projects",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch favorites",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Convert to response format
 	projectResponses := make([]models.ProjectResponse, len(projects))
 	for i, project := range projects {
 		projectResponses[i] = project.ToResponse()
+		projectResponses[i].Favorited = favorited[project.ID]
 	}
 
 	totalPages := int(math.Ceil(float64(total) / float64(limit)))
@@ -162,13 +375,16 @@ func (h *ProjectHandler) GetProjects(c *fiber.Ctx) error {
 
 // GetProject retrieves a project with its tasks
 func (h *ProjectHandler) GetProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	projectID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid project ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -176,45 +392,64 @@ func (h *ProjectHandler) GetProject(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
 	var project models.Project
-	if err := h.db.Preload("Owner").Preload("Tasks").Preload("Tasks.Assignee").
+	if err := db.Preload("Owner").Preload("Tasks").Preload("Tasks.Assignee").Preload("Tasks.Creator").
 		Where("id = ? AND owner_id = ?", projectID, currentUserID).
 		First(&project).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "Project not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch project",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	favorited, err := favoriteProjectIDs(db, currentUserID, project.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch favorites",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
+	response := project.ToResponseWithTasks()
+	response.Favorited = favorited[project.ID]
+
 	return c.JSON(models.SuccessResponse{
 		Message: "Project retrieved successfully",
-		Data:    project.ToResponseWithTasks(),
+		Data:    response,
 	})
 }
 
-// UpdateProject updates a project
-func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
+// GetProjectTrend returns the number of tasks created and completed per day
+func (h *ProjectHandler) GetProjectTrend(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	projectID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid project ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -222,85 +457,199 @@ func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
-	var req models.ProjectUpdateRequest
-	if err := c.BodyParser(&req); err != nil {
+	days, err := strconv.Atoi(c.Query("days", strconv.Itoa(defaultTrendDays)))
+	if err != nil || days < 1 {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "days must be a positive integer",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
 		})
 	}
+	if days > maxTrendDays {
+		days = maxTrendDays
+	}
 
-	// Find project
+	// Verify project exists and user owns it
 	var project models.Project
-	if err := h.db.Where("id = ? AND owner_id = ?", projectID, currentUserID).
+	if err := db.Where("id = ? AND owner_id = ?", projectID, currentUserID).
 		First(&project).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "Project not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch project",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Update fields
-	if req.Name != "" {
-		project.Name = req.Name
+	// Days are bucketed in UTC, ending with today
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	type dailyCount struct {
+		Day   time.Time
+		Count int64
+	}
+
+	var created []dailyCount
+	if err := db.Model(&models.Task{}).
+		Select("DATE_TRUNC('day', created_at AT TIME ZONE 'UTC') AS day, COUNT(*) AS count").
+		Where("project_id = ? AND created_at >= ?", projectID, since).
+		Group("day").
+		Scan(&created).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count created tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
 	}
-	if req.Description != nil {
-		project.Description = req.Description
+
+	var completed []dailyCount
+	if err := db.Model(&models.Task{}).
+		Select("DATE_TRUNC('day', completed_at AT TIME ZONE 'UTC') AS day, COUNT(*) AS count").
+		Where("project_id = ? AND completed_at >= ?", projectID, since).
+		Group("day").
+		Scan(&completed).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count completed tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
 	}
-	if req.Color != "" {
-		project.Color = req.Color
+
+	// Fill in every day of the window so charts have no gaps
+	points := make([]models.TrendPoint, days)
+	index := make(map[string]int, days)
+	for i := range points {
+		date := since.AddDate(0, 0, i).Format("2006-01-02")
+		points[i].Date = date
+		index[date] = i
+	}
+	for _, dc := range created {
+		if i, ok := index[dc.Day.Format("2006-01-02")]; ok {
+			points[i].Created = dc.Count
+		}
+	}
+	for _, dc := range completed {
+		if i, ok := index[dc.Day.Format("2006-01-02")]; ok {
+			points[i].Completed = dc.Count
+		}
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Project trend retrieved successfully",
+		Data: models.ProjectTrendResponse{
+			ProjectID: project.ID,
+			Days:      days,
+			Points:    points,
+		},
+	})
+}
+
+// GetProjectAssignees lists the distinct users assigned to tasks in a project,
+// e.g. for a board's assignee filter
+func (h *ProjectHandler) GetProjectAssignees(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
 	}
-	if req.Status != nil {
-		project.Status = *req.Status
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
 	}
 
-	if err := h.db.Save(&project).Error; err != nil {
+	// Verify project exists and user owns it
+	var project models.Project
+	if err := db.Where("id = ? AND owner_id = ?", projectID, currentUserID).
+		First(&project).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to update project",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Load the project with owner
-	if err := h.db.Preload("Owner").First(&project, project.ID).Error; err != nil {
+	// The inner join leaves out unassigned tasks
+	var assignees []models.User
+	if err := db.Distinct("users.*").
+		Joins("JOIN tasks ON tasks.assignee_id = users.id AND tasks.deleted_at IS NULL").
+		Where("tasks.project_id = ?", project.ID).
+		Order("users.first_name, users.last_name, users.id").
+		Find(&assignees).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to load project details",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch assignees",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
+	// Convert to response format
+	userResponses := make([]models.UserResponse, len(assignees))
+	for i, user := range assignees {
+		userResponses[i] = user.ToResponse()
+	}
+
 	return c.JSON(models.SuccessResponse{
-		Message: "Project updated successfully",
-		Data:    project.ToResponse(),
+		Message: "Project assignees retrieved successfully",
+		Data:    userResponses,
 	})
 }
 
-// DeleteProject deletes a project
-func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
+// GetProjectWorkload counts each assignee's tasks in the project so owners
+// can spot overloaded members. Archived tasks are left out.
+func (h *ProjectHandler) GetProjectWorkload(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	projectID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid project ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -308,33 +657,1041 @@ func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
-	// Delete project (this will also delete associated tasks due to foreign key constraints)
-	result := h.db.Where("id = ? AND owner_id = ?", projectID, currentUserID).
-		Delete(&models.Project{})
+	var filter models.WorkloadFilterQuery
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid query parameters",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+	if err := h.validate.Struct(filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
 
-	if result.Error != nil {
+	// Verify user owns the project
+	owned, err := h.ownership.Owns(db, currentUserID, projectID)
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to delete project",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !owned {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
 		})
 	}
 
-	if result.RowsAffected == 0 {
+	query := db.Model(&models.Task{}).
+		Where("tasks.project_id = ? AND tasks.archived_at IS NULL", projectID)
+	if filter.Status != "" {
+		query = query.Where("tasks.status = ?", filter.Status)
+	} else {
+		query = query.Scopes(models.OpenStatusScope)
+	}
+
+	// The left join keeps unassigned tasks as a row with a null assignee
+	var rows []struct {
+		AssigneeID *uuid.UUID
+		Email      string
+		FirstName  string
+		LastName   string
+		TaskCount  int64
+	}
+	if err := query.
+		Select("tasks.assignee_id, users.email, users.first_name, users.last_name, COUNT(*) AS task_count").
+		Joins("LEFT JOIN users ON users.id = tasks.assignee_id").
+		Group("tasks.assignee_id, users.email, users.first_name, users.last_name").
+		Order("task_count DESC, users.first_name, users.last_name, tasks.assignee_id").
+		Scan(&rows).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch workload",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	response := models.ProjectWorkloadResponse{
+		ProjectID: projectID,
+		Assignees: make([]models.AssigneeWorkload, 0, len(rows)),
+	}
+	for _, row := range rows {
+		if row.AssigneeID == nil {
+			response.Unassigned = row.TaskCount
+			continue
+		}
+		response.Assignees = append(response.Assignees, models.AssigneeWorkload{
+			AssigneeID: *row.AssigneeID,
+			Email:      row.Email,
+			FirstName:  row.FirstName,
+			LastName:   row.LastName,
+			TaskCount:  row.TaskCount,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Project workload retrieved successfully",
+		Data:    response,
+	})
+}
+
+// GetProjectPermissions reports what the caller may do with a project. Only
+// the owner can see a project, and the owner may do everything, so other
+// users get 404 as they do from every project endpoint.
+func (h *ProjectHandler) GetProjectPermissions(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Verify user owns the project
+	owned, err := h.ownership.Owns(db, currentUserID, projectID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !owned {
 		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-			Error:   "Not Found",
-			Message: "Project not found",
-			Code:    fiber.StatusNotFound,
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
 		})
 	}
 
+	// There are no members to manage until projects can be shared
 	return c.JSON(models.SuccessResponse{
-		Message: "Project deleted successfully",
+		Message: "Project permissions retrieved successfully",
+		Data: models.ProjectPermissionsResponse{
+			ProjectID:        projectID,
+			Role:             models.ProjectRoleOwner,
+			CanEdit:          true,
+			CanDelete:        true,
+			CanManageMembers: false,
+		},
+	})
+}
+
+// UpdateProject updates a project
+func (h *ProjectHandler) UpdateProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.ProjectUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Validate request
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	return h.saveProjectUpdate(c, db, projectID, currentUserID, req.Version, func(project *models.Project) {
+		if req.Name != "" {
+			project.Name = req.Name
+		}
+		if req.Description != nil {
+			project.Description = req.Description
+		}
+		if req.Color != "" {
+			project.Color = req.Color
+		}
+		if req.Status != nil {
+			project.Status = *req.Status
+		}
+		if req.DefaultTaskPriority != nil {
+			project.DefaultTaskPriority = *req.DefaultTaskPriority
+		}
+		if req.AutoEscalateOverdue != nil {
+			project.AutoEscalateOverdue = *req.AutoEscalateOverdue
+		}
+		if req.AutoStartOnAssign != nil {
+			project.AutoStartOnAssign = *req.AutoStartOnAssign
+		}
+	})
+}
+
+// PatchProject changes only the fields present in the JSON body. Unlike
+// UpdateProject, a null description clears it.
+func (h *ProjectHandler) PatchProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Form bodies cannot express null, so only JSON is accepted
+	if !c.Is("json") {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(models.ErrorResponse{
+			Error:     "Unsupported Media Type",
+			Message:   "PATCH requires an application/json body",
+			Code:      fiber.StatusUnsupportedMediaType,
+			ErrorCode: models.ErrUnsupportedMedia,
+		})
+	}
+
+	var req models.ProjectPatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Validate request
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	return h.saveProjectUpdate(c, db, projectID, currentUserID, req.Version, func(project *models.Project) {
+		if req.Name != nil {
+			project.Name = *req.Name
+		}
+		if req.Description.Set {
+			project.Description = req.Description.Value
+		}
+		if req.Color != nil {
+			project.Color = *req.Color
+		}
+		if req.Status != nil {
+			project.Status = *req.Status
+		}
+		if req.DefaultTaskPriority != nil {
+			project.DefaultTaskPriority = *req.DefaultTaskPriority
+		}
+		if req.AutoEscalateOverdue != nil {
+			project.AutoEscalateOverdue = *req.AutoEscalateOverdue
+		}
+		if req.AutoStartOnAssign != nil {
+			project.AutoStartOnAssign = *req.AutoStartOnAssign
+		}
+	})
+}
+
+// saveProjectUpdate applies changes to the user's project and saves it,
+// rejecting the update when version is given and stale or when another
+// request wins the race
+func (h *ProjectHandler) saveProjectUpdate(c *fiber.Ctx, db *gorm.DB, projectID, currentUserID uuid.UUID, version *int, apply func(*models.Project)) error {
+	// Find project
+	var project models.Project
+	if err := db.Where("id = ? AND owner_id = ?", projectID, currentUserID).
+		First(&project).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if version != nil && *version != project.Version {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   "Project was modified by another request; refetch it and retry",
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrVersionConflict,
+			Details:   models.ProjectVersionConflictDetails{CurrentVersion: project.Version},
+		})
+	}
+
+	apply(&project)
+
+	// The write only applies if the version is unchanged since the project
+	// was read, so concurrent updates cannot overwrite each other
+	readVersion := project.Version
+	project.Version++
+	result := db.Model(&project).Where("version = ?", readVersion).
+		Select("name", "description", "color", "status", "default_task_priority", "auto_escalate_overdue", "auto_start_on_assign", "version").
+		Updates(&project)
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to update project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   "Project was modified by another request; refetch it and retry",
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrVersionConflict,
+		})
+	}
+	h.ownership.InvalidateProject(project.ID)
+
+	// Load the project with owner
+	if err := primary(db).Preload("Owner").First(&project, project.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load project details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Project updated successfully",
+		Data:    project.ToResponse(),
+	})
+}
+
+// DeleteProject deletes a project
+func (h *ProjectHandler) DeleteProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Delete project (this will also delete associated tasks due to foreign key constraints)
+	result := db.Where("id = ? AND owner_id = ?", projectID, currentUserID).
+		Delete(&models.Project{})
+
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to delete project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
+		})
+	}
+	h.ownership.InvalidateProject(projectID)
+	h.broker.Publish(events.NewProjectEvent(events.ProjectDeleted, &models.Project{ID: projectID}))
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Project deleted successfully",
+	})
+}
+
+// DuplicateProject copies a project and, optionally, its tasks
+func (h *ProjectHandler) DuplicateProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.ProjectDuplicateRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "Invalid request body",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidRequestBody,
+			})
+		}
+		if err := h.validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Validation Error",
+				Message:   err.Error(),
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrValidation,
+			})
+		}
+	}
+
+	// Find source project with its tasks
+	var source models.Project
+	if err := db.Preload("Tasks").
+		Where("id = ? AND owner_id = ?", projectID, currentUserID).
+		First(&source).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	copyTasks := req.CopyTasks == nil || *req.CopyTasks
+
+	project := models.Project{
+		Name:                source.Name + " (Copy)",
+		Description:         source.Description,
+		Color:               source.Color,
+		OwnerID:             currentUserID,
+		Status:              models.ProjectStatusActive,
+		DefaultTaskPriority: source.DefaultTaskPriority,
+		AutoEscalateOverdue: source.AutoEscalateOverdue,
+		AutoStartOnAssign:   source.AutoStartOnAssign,
+	}
+
+	if req.Name != "" {
+		project.Name = req.Name
+	}
+
+	sendEmails := func() {}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&project).Error; err != nil {
+			return err
+		}
+
+		if !copyTasks || len(source.Tasks) == 0 {
+			return nil
+		}
+
+		tasks := make([]models.Task, len(source.Tasks))
+		for i, sourceTask := range source.Tasks {
+			tasks[i] = models.Task{
+				Title:       sourceTask.Title,
+				Description: sourceTask.Description,
+				ProjectID:   project.ID,
+				CreatedBy:   currentUserID,
+				Status:      models.TaskStatusTodo,
+				Priority:    sourceTask.Priority,
+				DueDate:     sourceTask.DueDate,
+			}
+			if req.CopyAssignees {
+				tasks[i].AssigneeID = sourceTask.AssigneeID
+			}
+		}
+
+		if err := tx.Create(&tasks).Error; err != nil {
+			return err
+		}
+
+		// Copied assignees are told about their new tasks
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, tasks)
+		return err
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to duplicate project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	sendEmails()
+
+	// Load the new project with owner and tasks
+	if err := primary(db).Preload("Owner").Preload("Tasks").Preload("Tasks.Assignee").Preload("Tasks.Creator").
+		First(&project, project.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load project details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
+		Message: "Project duplicated successfully",
+		Data:    project.ToResponseWithTasks(),
+	})
+}
+
+// TransferProject hands ownership of a project to another active user.
+// Admins may transfer any project, other users only projects they own.
+func (h *ProjectHandler) TransferProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.ProjectTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	if req.NewOwnerID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "new_owner_id is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Verify the new owner exists and is active
+	var newOwner models.User
+	if err := db.Where("id = ? AND is_active = ?", req.NewOwnerID, true).
+		First(&newOwner).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "New owner not found or inactive",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrNewOwnerUnavailable,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify new owner",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	admin, err := isAdmin(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify permissions",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Find project; admins may transfer any project, other users their own
+	query := db.Where("id = ?", projectID)
+	if !admin {
+		query = query.Where("owner_id = ?", currentUserID)
+	}
+	var project models.Project
+	if err := query.First(&project).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if project.OwnerID == newOwner.ID {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "The new owner already owns this project",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrAlreadyOwner,
+		})
+	}
+
+	pref, err := notificationPreference(db, newOwner.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch notification preferences",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	project.OwnerID = newOwner.ID
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&project).Updates(map[string]interface{}{
+			"owner_id": project.OwnerID,
+			"version":  gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		if !pref.Allows(models.NotificationTypeProjectTransferred) {
+			return nil
+		}
+		notification := models.NewProjectTransferredNotification(&project)
+		return tx.Create(&notification).Error
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to transfer project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	h.ownership.InvalidateProject(project.ID)
+
+	// Load the project with owner
+	if err := primary(db).Preload("Owner").First(&project, project.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load project details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	h.broker.Publish(events.NewProjectEvent(events.ProjectTransferred, &project))
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Project transferred successfully",
+		Data:    project.ToResponse(),
+	})
+}
+
+// FavoriteProject pins a project for the current user
+func (h *ProjectHandler) FavoriteProject(c *fiber.Ctx) error {
+	return h.setFavorite(c, true)
+}
+
+// UnfavoriteProject unpins a project for the current user
+func (h *ProjectHandler) UnfavoriteProject(c *fiber.Ctx) error {
+	return h.setFavorite(c, false)
+}
+
+// setFavorite adds or removes the current user's favorite. Both directions
+// are idempotent.
+func (h *ProjectHandler) setFavorite(c *fiber.Ctx, favorite bool) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var project models.Project
+	if err := db.Preload("Owner").
+		Where("id = ? AND owner_id = ?", projectID, currentUserID).
+		First(&project).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	favoriteRow := models.ProjectFavorite{UserID: currentUserID, ProjectID: project.ID}
+	if favorite {
+		err = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&favoriteRow).Error
+	} else {
+		err = db.Where("user_id = ? AND project_id = ?", currentUserID, project.ID).
+			Delete(&models.ProjectFavorite{}).Error
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to update favorite",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	response := project.ToResponse()
+	response.Favorited = favorite
+
+	message := "Project added to favorites"
+	if !favorite {
+		message = "Project removed from favorites"
+	}
+	return c.JSON(models.SuccessResponse{
+		Message: message,
+		Data:    response,
+	})
+}
+
+// ExportProject returns the project and its tasks as a document that
+// ImportProject accepts, for backups and moving projects between accounts
+func (h *ProjectHandler) ExportProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var project models.Project
+	if err := db.Preload("Tasks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	}).
+		Where("id = ? AND owner_id = ?", projectID, currentUserID).
+		First(&project).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// The document is returned bare, without the success envelope, so it can
+	// be posted to the import endpoint as is
+	c.Attachment(fmt.Sprintf("project-%s.json", project.ID))
+	return c.JSON(project.ToExport())
+}
+
+// ImportProject recreates an exported project and its tasks under the
+// current user with new IDs. Tasks are imported unassigned.
+func (h *ProjectHandler) ImportProject(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	var req models.ProjectExport
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	if req.Format != models.ProjectExportFormat {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   fmt.Sprintf("Unsupported export format %d; expected %d", req.Format, models.ProjectExportFormat),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Validate request
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Imports count against the same limits as creating by hand
+	quotaDetails, exceeded, err := h.checkProjectQuota(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count projects",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if exceeded {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   fmt.Sprintf("You can own at most %d projects", quotaDetails.Limit),
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrProjectQuota,
+			Details:   quotaDetails,
+		})
+	}
+	if limit := h.cfg.Tasks.MaxPerProject; limit > 0 && len(req.Tasks) > limit {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   fmt.Sprintf("Projects are limited to %d tasks", limit),
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrProjectTaskLimit,
+			Details:   models.TaskLimitDetails{Limit: limit, Requested: len(req.Tasks)},
+		})
+	}
+
+	project := models.Project{
+		Name:                req.Project.Name,
+		Description:         req.Project.Description,
+		Color:               req.Project.Color,
+		OwnerID:             currentUserID,
+		Status:              req.Project.Status,
+		DefaultTaskPriority: req.Project.DefaultTaskPriority,
+		AutoEscalateOverdue: req.Project.AutoEscalateOverdue,
+		AutoStartOnAssign:   req.Project.AutoStartOnAssign,
+	}
+	if project.Status == "" {
+		project.Status = models.ProjectStatusActive
+	}
+	if project.DefaultTaskPriority == "" {
+		project.DefaultTaskPriority = models.TaskPriorityMedium
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&project).Error; err != nil {
+			return err
+		}
+
+		if len(req.Tasks) == 0 {
+			return nil
+		}
+
+		tasks := make([]models.Task, len(req.Tasks))
+		for i, exported := range req.Tasks {
+			tasks[i] = models.Task{
+				Title:        exported.Title,
+				Description:  exported.Description,
+				ProjectID:    project.ID,
+				CreatedBy:    currentUserID,
+				Status:       exported.Status,
+				Priority:     exported.Priority,
+				DueDate:      exported.DueDate,
+				CompletedAt:  exported.CompletedAt,
+				SnoozedUntil: exported.SnoozedUntil,
+				EscalatedAt:  exported.EscalatedAt,
+				ArchivedAt:   exported.ArchivedAt,
+			}
+			if tasks[i].Status == "" {
+				tasks[i].Status = models.TaskStatusTodo
+			}
+			if tasks[i].Priority == "" {
+				tasks[i].Priority = project.DefaultTaskPriority
+			}
+		}
+
+		return tx.CreateInBatches(&tasks, 500).Error
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to import project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Load the new project with owner and tasks
+	if err := primary(db).Preload("Owner").Preload("Tasks").
+		First(&project, project.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load project details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
+		Message: "Project imported successfully",
+		Data:    project.ToResponseWithTasks(),
 	})
 }
@@ -0,0 +1,42 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestGetProjectTasksIncludeDeletedForAdmins(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	admin := testutil.CreateAdmin(t, app.DB, "admin@example.com")
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Support case")
+	kept := testutil.CreateTask(t, app.DB, project, "Kept", nil)
+	deleted := testutil.CreateTask(t, app.DB, project, "Deleted", nil)
+	if err := app.DB.Delete(&deleted).Error; err != nil {
+		t.Fatal(err)
+	}
+	path := "/api/v1/projects/" + project.ID.String() + "/tasks?include_deleted=true"
+
+	// An admin may look into another user's project
+	resp := app.Do(t, fiber.MethodGet, path, app.Token(t, admin), nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+	var body testapp.Response[[]models.TaskResponse]
+	testapp.Decode(t, resp, &body)
+	found := map[string]bool{}
+	for _, task := range body.Data {
+		found[task.ID.String()] = true
+	}
+	if !found[kept.ID.String()] || !found[deleted.ID.String()] {
+		t.Errorf("admin got %d tasks, want the kept and the deleted task", len(body.Data))
+	}
+
+	// Other users still may not include deleted records
+	other := testutil.CreateUser(t, app.DB, "other@example.com")
+	resp = app.Do(t, fiber.MethodGet, path, app.Token(t, other), nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusForbidden)
+}
@@ -0,0 +1,46 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAdminTransfersProject(t *testing.T) {
+	app := testapp.New(t)
+	admin := testutil.CreateAdmin(t, app.DB, "admin@example.com")
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	newOwner := testutil.CreateUser(t, app.DB, "new-owner@example.com")
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Handed over")
+	path := "/api/v1/projects/" + project.ID.String() + "/transfer"
+	token := app.Token(t, admin)
+
+	resp := app.Do(t, fiber.MethodPost, path, token, fiber.Map{"new_owner_id": newOwner.ID})
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+	var body testapp.Response[models.ProjectResponse]
+	testapp.Decode(t, resp, &body)
+	if body.Data.OwnerID != newOwner.ID {
+		t.Errorf("owner_id = %s, want %s", body.Data.OwnerID, newOwner.ID)
+	}
+
+	var stored models.Project
+	if err := app.DB.First(&stored, project.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.OwnerID != newOwner.ID {
+		t.Errorf("stored owner_id = %s, want %s", stored.OwnerID, newOwner.ID)
+	}
+
+	// Transferring to the current owner changes nothing
+	resp = app.Do(t, fiber.MethodPost, path, token, fiber.Map{"new_owner_id": newOwner.ID})
+	testapp.ExpectStatus(t, resp, fiber.StatusBadRequest)
+	var denied models.ErrorResponse
+	testapp.Decode(t, resp, &denied)
+	if denied.ErrorCode != models.ErrAlreadyOwner {
+		t.Errorf("error_code = %q, want %q", denied.ErrorCode, models.ErrAlreadyOwner)
+	}
+}
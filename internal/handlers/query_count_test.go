@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// TestListQueryCountIsConstant checks that list endpoints load relationships
+// in batches: serving more rows must not take more queries.
+func TestListQueryCountIsConstant(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	token := app.Token(t, owner)
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Counting")
+
+	var queries atomic.Int64
+	count := func(*gorm.DB) { queries.Add(1) }
+	callbacks := app.DB.Callback()
+	if err := callbacks.Query().After("gorm:query").Register("test:count_query", count); err != nil {
+		t.Fatal(err)
+	}
+	if err := callbacks.Row().After("gorm:row").Register("test:count_row", count); err != nil {
+		t.Fatal(err)
+	}
+	if err := callbacks.Raw().After("gorm:raw").Register("test:count_raw", count); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := []models.TaskStatus{models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusDone}
+	created := 0
+	addTasks := func(n int) {
+		t.Helper()
+		for range n {
+			// Every other task is the owner's so their assigned tasks grow too
+			assignee := owner
+			if created%2 == 1 {
+				assignee = testutil.CreateUser(t, app.DB, fmt.Sprintf("assignee%d@example.com", created))
+			}
+			task := testutil.CreateTask(t, app.DB, project, fmt.Sprintf("Match %d", created), &assignee.ID)
+			if err := app.DB.Model(&task).Update("status", statuses[created%len(statuses)]).Error; err != nil {
+				t.Fatal(err)
+			}
+			created++
+		}
+	}
+
+	paths := []string{
+		"/api/v1/projects",
+		"/api/v1/projects/" + project.ID.String() + "/tasks?limit=100",
+		"/api/v1/projects/" + project.ID.String() + "/board?limit=100",
+		"/api/v1/tasks?limit=100",
+		"/api/v1/tasks/search?q=match&limit=100",
+		"/api/v1/users/" + owner.ID.String() + "/assigned-tasks?limit=100",
+		"/api/v1/dashboard",
+	}
+	measure := func() map[string]int64 {
+		t.Helper()
+		counts := make(map[string]int64, len(paths))
+		for _, path := range paths {
+			queries.Store(0)
+			resp := app.Do(t, fiber.MethodGet, path, token, nil)
+			testapp.ExpectStatus(t, resp, fiber.StatusOK)
+			resp.Body.Close()
+			counts[path] = queries.Load()
+		}
+		return counts
+	}
+
+	addTasks(2)
+	few := measure()
+	addTasks(10)
+	many := measure()
+
+	for _, path := range paths {
+		if few[path] != many[path] {
+			t.Errorf("%s: %d queries for 2 tasks but %d for 12", path, few[path], many[path])
+		}
+	}
+}
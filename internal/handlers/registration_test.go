@@ -0,0 +1,41 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRegisterDuplicateEmailIgnoresCase(t *testing.T) {
+	app := testapp.New(t)
+	register := func(email string) {
+		t.Helper()
+
+		resp := app.Do(t, fiber.MethodPost, "/api/v1/auth/register", "", models.UserCreateRequest{
+			Email:     email,
+			Password:  "secret123",
+			FirstName: "Pat",
+			LastName:  "Doe",
+		})
+		testapp.ExpectStatus(t, resp, fiber.StatusConflict)
+		var body models.ErrorResponse
+		testapp.Decode(t, resp, &body)
+		if body.ErrorCode != models.ErrEmailTaken {
+			t.Errorf("error_code = %q, want %q", body.ErrorCode, models.ErrEmailTaken)
+		}
+	}
+
+	testutil.CreateUser(t, app.DB, "pat@example.com")
+	register("Pat@Example.COM")
+
+	// A row the lookup does not see, as when a concurrent registration commits
+	// between the check and the insert, is caught by the case-insensitive index
+	if err := app.DB.Exec(`UPDATE users SET email = 'Sam@Example.com' WHERE email = 'pat@example.com'`).Error; err != nil {
+		t.Fatal(err)
+	}
+	register("sam@example.com")
+}
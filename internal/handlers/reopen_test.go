@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestReopenTask(t *testing.T) {
+	app := testapp.New(t)
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	token := app.Token(t, owner)
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Reopening")
+	task := testutil.CreateTask(t, app.DB, project, "Done", nil)
+	path := "/api/v1/tasks/" + task.ID.String() + "/reopen"
+
+	// Only done tasks can be reopened
+	resp := app.Do(t, fiber.MethodPatch, path, token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusConflict)
+
+	completedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Microsecond)
+	if err := app.DB.Model(&task).Updates(map[string]interface{}{
+		"status":       models.TaskStatusDone,
+		"completed_at": completedAt,
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	resp = app.Do(t, fiber.MethodPatch, path, token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+	var body testapp.Response[models.TaskResponse]
+	testapp.Decode(t, resp, &body)
+	if body.Data.Status != models.TaskStatusTodo || body.Data.CompletedAt != nil {
+		t.Errorf("response: status = %s, completed_at = %v; want todo and null", body.Data.Status, body.Data.CompletedAt)
+	}
+
+	var stored models.Task
+	if err := app.DB.First(&stored, task.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != models.TaskStatusTodo || stored.CompletedAt != nil {
+		t.Errorf("stored: status = %s, completed_at = %v; want todo and null", stored.Status, stored.CompletedAt)
+	}
+
+	var activity models.TaskActivity
+	if err := app.DB.Where("task_id = ? AND action = ?", task.ID, models.TaskActivityReopened).
+		First(&activity).Error; err != nil {
+		t.Fatalf("no reopened activity: %v", err)
+	}
+	if activity.ActorID == nil || *activity.ActorID != owner.ID {
+		t.Errorf("activity actor = %v, want %s", activity.ActorID, owner.ID)
+	}
+}
+
+func TestReopenTaskRecordsImpersonator(t *testing.T) {
+	app := testapp.New(t)
+	admin := testutil.CreateAdmin(t, app.DB, "admin@example.com")
+	owner := testutil.CreateUser(t, app.DB, "owner@example.com")
+	project := testutil.CreateProject(t, app.DB, owner.ID, "Reopening")
+	task := testutil.CreateTask(t, app.DB, project, "Done", nil)
+	if err := app.DB.Model(&task).Update("status", models.TaskStatusDone).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	token, _, err := middleware.GenerateImpersonationJWT(&owner, admin.ID, app.Cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := app.Do(t, fiber.MethodPatch, "/api/v1/tasks/"+task.ID.String()+"/reopen", token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+
+	var activity models.TaskActivity
+	if err := app.DB.Where("task_id = ? AND action = ?", task.ID, models.TaskActivityReopened).
+		First(&activity).Error; err != nil {
+		t.Fatalf("no reopened activity: %v", err)
+	}
+	if activity.ActorID == nil || *activity.ActorID != owner.ID {
+		t.Errorf("activity actor = %v, want %s", activity.ActorID, owner.ID)
+	}
+	var payload struct {
+		ImpersonatedBy uuid.UUID `json:"impersonated_by"`
+	}
+	if err := json.Unmarshal(activity.Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.ImpersonatedBy != admin.ID {
+		t.Errorf("impersonated_by = %s, want %s", payload.ImpersonatedBy, admin.ID)
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// TextSearch builds the case-insensitive substring matches used by task
+// search. With unaccent it also ignores accents, so "cafe" finds "café".
+type TextSearch struct {
+	unaccent bool
+}
+
+// NewTextSearch enables accent-insensitive matching when it is configured and
+// the unaccent extension is installed, falling back to plain ILIKE otherwise
+func NewTextSearch(db *gorm.DB, unaccent bool) (*TextSearch, error) {
+	if !unaccent {
+		return &TextSearch{}, nil
+	}
+
+	var installed bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'unaccent')").
+		Scan(&installed).Error; err != nil {
+		return nil, err
+	}
+	if !installed {
+		log.Println("⚠️  TASK_SEARCH_UNACCENT is enabled but the unaccent extension is not installed; searching with plain ILIKE")
+	}
+	return &TextSearch{unaccent: installed}, nil
+}
+
+// Contains returns a condition matching column against a LIKE pattern bound
+// to its single parameter
+func (s *TextSearch) Contains(column string) string {
+	if s.unaccent {
+		return "unaccent(" + column + ") ILIKE unaccent(?)"
+	}
+	return column + " ILIKE ?"
+}
@@ -1,57 +1,2612 @@
 package handlers
 
 import (
+	"fmt"
 	"math"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/mailer"
 	"taskflow-api/internal/middleware"
 	"taskflow-api/internal/models"
+	"taskflow-api/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// defaultRecentTaskLimit is how many recently viewed tasks are returned when
+// limit is not given
+const defaultRecentTaskLimit = 10
+
 type TaskHandler struct {
-	db       *gorm.DB
-	validate *validator.Validate
+	db        *gorm.DB
+	cfg       *config.Config
+	broker    *events.Broker
+	mail      *mailer.Queue
+	values    *validation.TaskValues
+	ownership *OwnershipCache
+	views     *TaskViewRecorder
+	search    *TextSearch
+	validate  *validator.Validate
 }
 
-func NewTaskHandler(db *gorm.DB) *TaskHandler {
+func NewTaskHandler(db *gorm.DB, cfg *config.Config, broker *events.Broker, mail *mailer.Queue, values *validation.TaskValues, ownership *OwnershipCache, views *TaskViewRecorder, search *TextSearch) *TaskHandler {
 	return &TaskHandler{
-		db:       db,
-		validate: validator.New(),
+		db:        db,
+		cfg:       cfg,
+		broker:    broker,
+		mail:      mail,
+		values:    values,
+		ownership: ownership,
+		views:     views,
+		search:    search,
+		validate:  validation.New(cfg, values),
+	}
+}
+
+// rejectsPastDueDate reports whether a create request must be refused because
+// its due date has already passed
+func (h *TaskHandler) rejectsPastDueDate(req models.TaskCreateRequest) bool {
+	return h.cfg.Tasks.RejectPastDueDate && !req.AllowPastDueDate &&
+		req.DueDate != nil && req.DueDate.Before(time.Now())
+}
+
+// exceedsDueDateHorizon reports whether a due date lies further ahead than
+// TASK_MAX_DUE_DATE_DAYS allows
+func (h *TaskHandler) exceedsDueDateHorizon(dueDate *time.Time) bool {
+	days := h.cfg.Tasks.MaxDueDateDays
+	return days > 0 && dueDate != nil && dueDate.After(time.Now().AddDate(0, 0, days))
+}
+
+// dueDateTooFarMessage explains a due date rejected by exceedsDueDateHorizon
+func (h *TaskHandler) dueDateTooFarMessage() string {
+	return fmt.Sprintf("due_date must be at most %d days from now", h.cfg.Tasks.MaxDueDateDays)
+}
+
+// newTaskFromRequest builds a task for the given project from a create request,
+// falling back to todo and the project's default priority when none is given.
+// The BeforeCreate hook sets completed_at for tasks created as done.
+func newTaskFromRequest(project *models.Project, creatorID uuid.UUID, req models.TaskCreateRequest) models.Task {
+	task := models.Task{
+		Title:     req.Title,
+		ProjectID: project.ID,
+		CreatedBy: creatorID,
+		Status:    models.TaskStatusTodo,
+		Priority:  project.DefaultTaskPriority,
+	}
+
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityMedium
+	}
+
+	if req.Description != "" {
+		task.Description = &req.Description
+	}
+	if req.AssigneeID != nil {
+		task.AssigneeID = req.AssigneeID
+	}
+	if req.Status != nil {
+		task.Status = *req.Status
+	}
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+	if req.DueDate != nil {
+		task.DueDate = req.DueDate
+	}
+
+	return task
+}
+
+// checkTaskLimit reports whether adding tasks to a project would exceed the
+// configured per-project limit. Soft-deleted tasks do not count.
+func (h *TaskHandler) checkTaskLimit(db *gorm.DB, projectID uuid.UUID, adding int) (models.TaskLimitDetails, bool, error) {
+	details := models.TaskLimitDetails{
+		Limit:     h.cfg.Tasks.MaxPerProject,
+		Requested: adding,
+	}
+	if details.Limit <= 0 {
+		return details, false, nil
 	}
+
+	if err := db.Model(&models.Task{}).Where("project_id = ?", projectID).
+		Count(&details.Current).Error; err != nil {
+		return details, false, err
+	}
+
+	return details, details.Current+int64(adding) > int64(details.Limit), nil
 }
 
 // CreateTask creates a new task in a project
 func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	projectID := c.Params("project_id")
 	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid project ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
 	var req models.TaskCreateRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
 		})
 	}
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Validation Error",
-			Message: err.Error(),
-			Code:    fiber.StatusBadRequest,
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	if h.rejectsPastDueDate(req) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(models.ErrorResponse{
+			Error:     "Unprocessable Entity",
+			Message:   "due_date is in the past; set allow_past_due_date to create the task anyway",
+			Code:      fiber.StatusUnprocessableEntity,
+			ErrorCode: models.ErrDueDateInPast,
+		})
+	}
+	if h.exceedsDueDateHorizon(req.DueDate) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(models.ErrorResponse{
+			Error:     "Unprocessable Entity",
+			Message:   h.dueDateTooFarMessage(),
+			Code:      fiber.StatusUnprocessableEntity,
+			ErrorCode: models.ErrDueDateTooFar,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Verify project exists and user owns it
+	var project models.Project
+	if err := db.Where("id = ? AND owner_id = ?", projectUUID, currentUserID).
+		First(&project).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Enforce the per-project task limit
+	limitDetails, exceeded, err := h.checkTaskLimit(db, project.ID, 1)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if exceeded {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   fmt.Sprintf("Projects are limited to %d tasks", limitDetails.Limit),
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrProjectTaskLimit,
+			Details:   limitDetails,
+		})
+	}
+
+	// Create task
+	task := newTaskFromRequest(&project, currentUserID, req)
+
+	var sendEmails func()
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, []models.Task{task})
+		return err
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to create task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	sendEmails()
+
+	// Load the task with relationships
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&task, task.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	h.broker.Publish(events.NewTaskEvent(events.TaskCreated, &task))
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
+		Message: "Task created successfully",
+		Data:    task.ToResponse(),
+	})
+}
+
+// BulkCreateTasks creates several tasks in a project within a single transaction
+func (h *TaskHandler) BulkCreateTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	projectID := c.Params("project_id")
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	var reqs []models.TaskCreateRequest
+	if err := c.BodyParser(&reqs); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	if len(reqs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "At least one task is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrBulkEmpty,
+		})
+	}
+
+	if len(reqs) > h.cfg.Tasks.BulkMaxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   fmt.Sprintf("A maximum of %d tasks can be created at once", h.cfg.Tasks.BulkMaxSize),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrBulkTooLarge,
+		})
+	}
+
+	// Validate every task before creating any of them
+	var validationErrors []models.BulkItemError
+	for i, req := range reqs {
+		if err := h.validate.Struct(req); err != nil {
+			validationErrors = append(validationErrors, models.BulkItemError{
+				Index:   i,
+				Message: err.Error(),
+			})
+		} else if h.rejectsPastDueDate(req) {
+			validationErrors = append(validationErrors, models.BulkItemError{
+				Index:   i,
+				Message: "due_date is in the past; set allow_past_due_date to create the task anyway",
+			})
+		} else if h.exceedsDueDateHorizon(req.DueDate) {
+			validationErrors = append(validationErrors, models.BulkItemError{
+				Index:   i,
+				Message: h.dueDateTooFarMessage(),
+			})
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "One or more tasks are invalid",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrBulkInvalid,
+			Details:   validationErrors,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Verify project exists and user owns it
+	var project models.Project
+	if err := db.Where("id = ? AND owner_id = ?", projectUUID, currentUserID).
+		First(&project).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Project not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrProjectNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Enforce the per-project task limit
+	limitDetails, exceeded, err := h.checkTaskLimit(db, project.ID, len(reqs))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if exceeded {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   fmt.Sprintf("Projects are limited to %d tasks", limitDetails.Limit),
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrProjectTaskLimit,
+			Details:   limitDetails,
+		})
+	}
+
+	// Create tasks
+	tasks := make([]models.Task, len(reqs))
+	for i, req := range reqs {
+		tasks[i] = newTaskFromRequest(&project, currentUserID, req)
+	}
+
+	var sendEmails func()
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&tasks).Error; err != nil {
+			return err
+		}
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, tasks)
+		return err
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to create tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	sendEmails()
+
+	// Load the tasks with relationships, preserving the request order
+	taskIDs := make([]uuid.UUID, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+
+	var created []models.Task
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").
+		Where("id IN ?", taskIDs).Find(&created).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	createdByID := make(map[uuid.UUID]models.Task, len(created))
+	for _, task := range created {
+		createdByID[task.ID] = task
+	}
+
+	taskResponses := make([]models.TaskResponse, len(taskIDs))
+	for i, id := range taskIDs {
+		task := createdByID[id]
+		taskResponses[i] = task.ToResponse()
+		h.broker.Publish(events.NewTaskEvent(events.TaskCreated, &task))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
+		Message: "Tasks created successfully",
+		Data:    taskResponses,
+	})
+}
+
+// BulkAssignTasks assigns or unassigns several tasks of a project in one
+// transaction. Nothing changes unless every task belongs to the project.
+func (h *TaskHandler) BulkAssignTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	projectID := c.Params("project_id")
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	var req models.TaskBulkAssignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Repeated IDs count once
+	var taskIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool, len(req.TaskIDs))
+	for _, id := range req.TaskIDs {
+		if !seen[id] {
+			seen[id] = true
+			taskIDs = append(taskIDs, id)
+		}
+	}
+
+	if len(taskIDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "At least one task is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrBulkEmpty,
+		})
+	}
+
+	if len(taskIDs) > h.cfg.Tasks.BulkMaxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   fmt.Sprintf("A maximum of %d tasks can be assigned at once", h.cfg.Tasks.BulkMaxSize),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrBulkTooLarge,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Verify user owns the project; writes never trust the ownership cache
+	owned, err := ownsProject(db, currentUserID, projectUUID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !owned {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
+		})
+	}
+
+	// Verify the new assignee exists and is active
+	if req.AssigneeID != nil {
+		var assignee models.User
+		if err := db.Where("id = ? AND is_active = ?", *req.AssigneeID, true).
+			First(&assignee).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+					Error:     "Bad Request",
+					Message:   "Assignee not found or inactive",
+					Code:      fiber.StatusBadRequest,
+					ErrorCode: models.ErrAssigneeUnavailable,
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify assignee",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Assigning todo tasks starts them when the project opts in
+	startOnAssign := false
+	if req.AssigneeID != nil {
+		startOnAssign, err = autoStartsOnAssign(db, projectUUID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch project settings",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	var missing, changed, started []uuid.UUID
+	sendEmails := func() {}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		var tasks []models.Task
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND project_id = ?", taskIDs, projectUUID).
+			Find(&tasks).Error; err != nil {
+			return err
+		}
+
+		found := make(map[uuid.UUID]bool, len(tasks))
+		for _, task := range tasks {
+			found[task.ID] = true
+		}
+		for _, id := range taskIDs {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// Tasks already with the assignee are left alone
+		var changedTasks []models.Task
+		for _, task := range tasks {
+			if sameAssignee(task.AssigneeID, req.AssigneeID) {
+				continue
+			}
+			changed = append(changed, task.ID)
+			if startOnAssign && task.Status == models.TaskStatusTodo {
+				started = append(started, task.ID)
+			}
+			task.AssigneeID = req.AssigneeID
+			changedTasks = append(changedTasks, task)
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&models.Task{}).Where("id IN ?", changed).
+			Update("assignee_id", req.AssigneeID).Error; err != nil {
+			return err
+		}
+		if len(started) > 0 {
+			if err := tx.Model(&models.Task{}).Where("id IN ?", started).
+				Update("status", models.TaskStatusInProgress).Error; err != nil {
+				return err
+			}
+			for _, id := range started {
+				if err := recordAutoStart(c, tx, id, currentUserID, req.AssigneeID); err != nil {
+					return err
+				}
+			}
+		}
+
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, changedTasks)
+		return err
+	}); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "One or more tasks were not found in the project",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+				Details:   models.TasksNotFoundDetails{TaskIDs: missing},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to assign tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	sendEmails()
+
+	// Publish the changes to live subscribers
+	if len(changed) > 0 {
+		var updated []models.Task
+		if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").Where("id IN ?", changed).Find(&updated).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to load task details",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+		for i := range updated {
+			h.broker.Publish(events.NewTaskEvent(assignmentEvent(slices.Contains(started, updated[i].ID)), &updated[i]))
+		}
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Tasks assigned successfully",
+		Data:    models.TaskBulkAssignResponse{Updated: len(changed)},
+	})
+}
+
+// sameAssignee reports whether two optional assignees are the same user
+func sameAssignee(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// autoStartsOnAssign reports whether the project moves todo tasks to
+// in_progress when they are assigned
+func autoStartsOnAssign(db *gorm.DB, projectID uuid.UUID) (bool, error) {
+	var project models.Project
+	if err := db.Select("auto_start_on_assign").First(&project, projectID).Error; err != nil {
+		return false, err
+	}
+	return project.AutoStartOnAssign, nil
+}
+
+// autoStart moves a todo task that just got a new assignee to in_progress
+// when its project opts in, reporting whether it did
+func autoStart(db *gorm.DB, task *models.Task, previousAssignee *uuid.UUID) (bool, error) {
+	if task.AssigneeID == nil || sameAssignee(previousAssignee, task.AssigneeID) || task.Status != models.TaskStatusTodo {
+		return false, nil
+	}
+	enabled, err := autoStartsOnAssign(db, task.ProjectID)
+	if err != nil || !enabled {
+		return false, err
+	}
+	task.Status = models.TaskStatusInProgress
+	return true, nil
+}
+
+// recordAutoStart adds an auto_started entry to the activity log of a task
+// that assigning it to assigneeID moved to in_progress
+func recordAutoStart(c *fiber.Ctx, tx *gorm.DB, taskID, actorID uuid.UUID, assigneeID *uuid.UUID) error {
+	return recordTaskActivity(c, tx, taskID, &actorID, models.TaskActivityAutoStarted, map[string]interface{}{
+		"from":        models.TaskStatusTodo,
+		"to":          models.TaskStatusInProgress,
+		"assignee_id": assigneeID,
+	})
+}
+
+// assignmentEvent is the event for a task whose assignee changed
+func assignmentEvent(autoStarted bool) events.Type {
+	if autoStarted {
+		return events.TaskAutoStarted
+	}
+	return events.TaskUpdated
+}
+
+// BulkUpdateTasks applies the same status, priority, assignee and due date
+// changes to several tasks in a project within a single transaction. Nothing
+// changes unless every task belongs to the project.
+func (h *TaskHandler) BulkUpdateTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	projectID := c.Params("project_id")
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	var req models.TaskBulkUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Repeated IDs count once
+	var taskIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if !seen[id] {
+			seen[id] = true
+			taskIDs = append(taskIDs, id)
+		}
+	}
+
+	if len(taskIDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "At least one task is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrBulkEmpty,
+		})
+	}
+
+	if len(taskIDs) > h.cfg.Tasks.BulkMaxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   fmt.Sprintf("A maximum of %d tasks can be updated at once", h.cfg.Tasks.BulkMaxSize),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrBulkTooLarge,
+		})
+	}
+
+	// The fields apply to every task, so they are validated once
+	if req.Status == nil && req.Priority == nil && req.AssigneeID == nil && req.DueDate == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "At least one of status, priority, assignee_id or due_date is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+	if h.exceedsDueDateHorizon(req.DueDate) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(models.ErrorResponse{
+			Error:     "Unprocessable Entity",
+			Message:   h.dueDateTooFarMessage(),
+			Code:      fiber.StatusUnprocessableEntity,
+			ErrorCode: models.ErrDueDateTooFar,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Verify user owns the project; writes never trust the ownership cache
+	owned, err := ownsProject(db, currentUserID, projectUUID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !owned {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
+		})
+	}
+
+	// Verify the new assignee exists and is active
+	if req.AssigneeID != nil {
+		var assignee models.User
+		if err := db.Where("id = ? AND is_active = ?", *req.AssigneeID, true).
+			First(&assignee).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+					Error:     "Bad Request",
+					Message:   "Assignee not found or inactive",
+					Code:      fiber.StatusBadRequest,
+					ErrorCode: models.ErrAssigneeUnavailable,
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify assignee",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// An explicit status wins over starting the tasks automatically
+	startOnAssign := false
+	if req.AssigneeID != nil && req.Status == nil {
+		startOnAssign, err = autoStartsOnAssign(db, projectUUID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch project settings",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	var missing, changed, started []uuid.UUID
+	sendEmails := func() {}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		var tasks []models.Task
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND project_id = ?", taskIDs, projectUUID).
+			Find(&tasks).Error; err != nil {
+			return err
+		}
+
+		found := make(map[uuid.UUID]bool, len(tasks))
+		for _, task := range tasks {
+			found[task.ID] = true
+		}
+		for _, id := range taskIDs {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// Tasks that already have the requested values are left alone
+		var reassigned []models.Task
+		for i := range tasks {
+			task := &tasks[i]
+			updated := false
+			if req.Status != nil && task.Status != *req.Status {
+				task.Status = *req.Status
+				updated = true
+			}
+			if req.Priority != nil && task.Priority != *req.Priority {
+				task.Priority = *req.Priority
+				updated = true
+			}
+			assigneeChanged := req.AssigneeID != nil && !sameAssignee(task.AssigneeID, req.AssigneeID)
+			if assigneeChanged {
+				task.AssigneeID = req.AssigneeID
+				updated = true
+				if startOnAssign && task.Status == models.TaskStatusTodo {
+					task.Status = models.TaskStatusInProgress
+					started = append(started, task.ID)
+				}
+			}
+			if req.DueDate != nil && (task.DueDate == nil || !task.DueDate.Equal(*req.DueDate)) {
+				// A new due date makes the task eligible for escalation and an
+				// overdue notice again
+				task.DueDate = req.DueDate
+				task.EscalatedAt = nil
+				task.OverdueNotifiedAt = nil
+				updated = true
+			}
+			if !updated {
+				continue
+			}
+
+			if err := tx.Save(task).Error; err != nil {
+				return err
+			}
+			if slices.Contains(started, task.ID) {
+				if err := recordAutoStart(c, tx, task.ID, currentUserID, task.AssigneeID); err != nil {
+					return err
+				}
+			}
+			changed = append(changed, task.ID)
+			if assigneeChanged {
+				reassigned = append(reassigned, *task)
+			}
+		}
+
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, reassigned)
+		return err
+	}); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "One or more tasks were not found in the project",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+				Details:   models.TasksNotFoundDetails{TaskIDs: missing},
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to update tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	sendEmails()
+
+	var tasks []models.Task
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").Where("id IN ?", taskIDs).Find(&tasks).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	byID := make(map[uuid.UUID]*models.Task, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+
+	// Report every task in request order and publish the changed ones
+	results := make([]models.TaskBulkUpdateResult, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		task, ok := byID[id]
+		if !ok {
+			continue
+		}
+		updated := slices.Contains(changed, id)
+		if updated {
+			h.broker.Publish(events.NewTaskEvent(assignmentEvent(slices.Contains(started, id)), task))
+		}
+		results = append(results, models.TaskBulkUpdateResult{
+			ID:      id,
+			Updated: updated,
+			Task:    task.ToResponse(),
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Tasks updated successfully",
+		Data:    results,
+	})
+}
+
+// GetProjectTasks retrieves tasks for a specific project
+func (h *TaskHandler) GetProjectTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	projectID := c.Params("project_id")
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Soft-deleted records are only visible to admins
+	query := db
+	includeDeleted := c.QueryBool("include_deleted")
+	if includeDeleted {
+		admin, err := isAdmin(db, currentUserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify permissions",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+		if !admin {
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+				Error:     "Forbidden",
+				Message:   "Only admins can include deleted records",
+				Code:      fiber.StatusForbidden,
+				ErrorCode: models.ErrAdminRequired,
+			})
+		}
+		query = db.Unscoped()
+	}
+
+	// Verify user owns the project. Admins including deleted records may look
+	// into anyone's project, and deleted projects bypass the cache.
+	var owned bool
+	if includeDeleted {
+		owned, err = projectExists(query, projectUUID)
+	} else {
+		owned, err = h.ownership.Owns(db, currentUserID, projectUUID)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !owned {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
+		})
+	}
+
+	// Parse pagination parameters
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultTaskLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	orderBy, err := parseSort(c, h.cfg.Pagination, taskSortFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	fields, err := parseFields(c, taskResponseFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	var tasks []models.Task
+	var total int64
+
+	// Parse filters
+	var filter models.TaskFilterQuery
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid query parameters",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+	if err := h.validate.Struct(filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	var assigneeID *uuid.UUID
+	if filter.AssigneeID == models.AssigneeNone {
+		filter.Unassigned = true
+	} else if filter.AssigneeID != "" {
+		parsed, err := uuid.Parse(filter.AssigneeID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "assignee_id must be a user ID or \"none\"",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidQuery,
+			})
+		}
+		assigneeID = &parsed
+	}
+	if filter.Unassigned && assigneeID != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "unassigned cannot be combined with an assignee_id",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	// Snoozed and archived tasks are hidden unless explicitly requested
+	includeSnoozed := c.QueryBool("include_snoozed")
+	includeArchived := c.QueryBool("include_archived")
+	projectTasks := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("project_id = ?", projectUUID)
+		if !includeSnoozed {
+			db = db.Where("snoozed_until IS NULL OR snoozed_until <= ?", time.Now())
+		}
+		if !includeArchived {
+			db = db.Where("archived_at IS NULL")
+		}
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+		if filter.Priority != "" {
+			db = db.Where("priority = ?", filter.Priority)
+		}
+		if filter.Unassigned {
+			db = db.Where("assignee_id IS NULL")
+		} else if assigneeID != nil {
+			db = db.Where("assignee_id = ?", *assigneeID)
+		}
+		return db
+	}
+
+	// Count total tasks for the project
+	if err := query.Model(&models.Task{}).Scopes(projectTasks).Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Get tasks with pagination
+	if !pastLastPage(offset, total) {
+		if err := query.Preload("Project").Preload("Assignee").Preload("Creator").
+			Scopes(projectTasks).
+			Order(orderBy).
+			Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch tasks",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Convert to response format, keeping only the requested fields
+	taskResponses := make([]interface{}, len(tasks))
+	for i, task := range tasks {
+		if taskResponses[i], err = selectFields(task.ToResponse(), fields); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to encode tasks",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	return c.JSON(models.ListResponse{
+		Data: taskResponses,
+		Pagination: models.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// GetProjectTaskChanges lists a project's tasks created, updated or deleted
+// after the since timestamp, so offline clients can sync only what changed.
+// Deleted tasks are returned as tombstones.
+func (h *TaskHandler) GetProjectTaskChanges(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	projectID := c.Params("project_id")
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	since, err := time.Parse(time.RFC3339, c.Query("since"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "since must be an RFC 3339 timestamp",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	// Verify user owns the project
+	owned, err := h.ownership.Owns(db, currentUserID, projectUUID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !owned {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
+		})
+	}
+
+	// Take the cursor before querying so changes made while the query runs
+	// are returned again next time rather than missed. Read from the primary
+	// so a lagging replica can't hide changes before the cursor.
+	serverTime := time.Now()
+
+	// Deleting a task only sets deleted_at, so check both columns
+	var tasks []models.Task
+	if err := primary(db).Unscoped().Preload("Assignee").Preload("Creator").
+		Where("project_id = ?", projectUUID).
+		Where("updated_at > ? OR deleted_at > ?", since, since).
+		Order("updated_at, id").
+		Find(&tasks).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task changes",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	changes := models.TaskChangesResponse{
+		Tasks:      []models.TaskResponse{},
+		Deleted:    []models.TaskTombstone{},
+		ServerTime: serverTime,
+	}
+	for _, task := range tasks {
+		if task.DeletedAt.Valid {
+			changes.Deleted = append(changes.Deleted, models.TaskTombstone{
+				ID:        task.ID,
+				Deleted:   true,
+				DeletedAt: task.DeletedAt.Time,
+			})
+			continue
+		}
+		changes.Tasks = append(changes.Tasks, task.ToResponse())
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Task changes retrieved successfully",
+		Data:    changes,
+	})
+}
+
+// QueryProjectTasks lists a project's tasks matching a structured filter of
+// field/operator/value conditions combined with and/or groups
+func (h *TaskHandler) QueryProjectTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	projectID := c.Params("project_id")
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.TaskQueryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Translate the filter before touching the database
+	var clause string
+	var args []interface{}
+	if req.Filter != nil {
+		builder := &taskQueryBuilder{values: h.values, search: h.search}
+		clause, args, err = builder.build(*req.Filter, 1)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Validation Error",
+				Message:   err.Error(),
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrValidation,
+			})
+		}
+	}
+
+	// Verify user owns the project
+	owned, err := h.ownership.Owns(db, currentUserID, projectUUID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !owned {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
+		})
+	}
+
+	// Parse pagination parameters
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultTaskLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	orderBy, err := parseSort(c, h.cfg.Pagination, taskSortFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	matchingTasks := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("tasks.project_id = ?", projectUUID)
+		if clause != "" {
+			db = db.Where(clause, args...)
+		}
+		return db
+	}
+
+	var tasks []models.Task
+	var total int64
+
+	if err := db.Model(&models.Task{}).Scopes(matchingTasks).Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if !pastLastPage(offset, total) {
+		if err := db.Preload("Project").Preload("Assignee").Preload("Creator").
+			Scopes(matchingTasks).
+			Order(orderBy).
+			Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch tasks",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Convert to response format
+	taskResponses := make([]models.TaskResponse, len(tasks))
+	for i, task := range tasks {
+		taskResponses[i] = task.ToResponse()
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	return c.JSON(models.ListResponse{
+		Data: taskResponses,
+		Pagination: models.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// GetProjectBoard returns a project's tasks grouped into one column per
+// status. page and limit apply to each column separately.
+func (h *TaskHandler) GetProjectBoard(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	projectID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid project ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Verify user owns the project
+	owned, err := h.ownership.Owns(db, currentUserID, projectID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify project",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !owned {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "Project not found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrProjectNotFound,
+		})
+	}
+
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultTaskLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	// Snoozed and archived tasks are hidden unless explicitly requested
+	includeSnoozed := c.QueryBool("include_snoozed")
+	includeArchived := c.QueryBool("include_archived")
+	boardTasks := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("project_id = ?", projectID)
+		if !includeSnoozed {
+			db = db.Where("snoozed_until IS NULL OR snoozed_until <= ?", time.Now())
+		}
+		if !includeArchived {
+			db = db.Where("archived_at IS NULL")
+		}
+		return db
+	}
+
+	// Count every column in one query
+	type statusCount struct {
+		Status models.TaskStatus
+		Count  int64
+	}
+	var counts []statusCount
+	if err := db.Model(&models.Task{}).Scopes(boardTasks).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&counts).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Every status gets a column, including custom ones
+	statuses := h.values.Statuses()
+	columns := make(map[models.TaskStatus]models.BoardColumn, len(statuses))
+	for _, status := range statuses {
+		columns[models.TaskStatus(status)] = models.BoardColumn{Tasks: []models.TaskResponse{}}
+	}
+	var longest int64
+	for _, sc := range counts {
+		column := columns[sc.Status]
+		column.Total = sc.Count
+		columns[sc.Status] = column
+		longest = max(longest, sc.Count)
+	}
+
+	// Fetch a page of every column in one query, most urgent first, so the
+	// number of queries does not grow with the number of statuses
+	ranked := db.Model(&models.Task{}).Scopes(boardTasks).
+		Select("tasks.*, ROW_NUMBER() OVER (PARTITION BY status ORDER BY priority DESC, " + stableOrder + ") AS board_rank")
+
+	var tasks []models.Task
+	if !pastLastPage(offset, longest) {
+		if err := db.Table("(?) AS tasks", ranked).
+			Preload("Assignee").Preload("Creator").
+			Where("board_rank > ? AND board_rank <= ?", offset, offset+limit).
+			Order("board_rank").
+			Find(&tasks).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch tasks",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	for _, task := range tasks {
+		column := columns[task.Status]
+		column.Tasks = append(column.Tasks, task.ToResponse())
+		columns[task.Status] = column
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Project board retrieved successfully",
+		Data: models.ProjectBoardResponse{
+			ProjectID: projectID,
+			Page:      page,
+			Limit:     limit,
+			Columns:   columns,
+		},
+	})
+}
+
+// parseUserFilter resolves a user filter value, which is a user ID or "me"
+// for the current user. An empty value returns nil.
+func parseUserFilter(value string, currentUserID uuid.UUID) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if value == models.UserMe {
+		return &currentUserID, nil
+	}
+	userID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &userID, nil
+}
+
+// ListTasks lists the tasks in every project the current user owns, optionally
+// narrowed to the tasks a user created or is assigned
+func (h *TaskHandler) ListTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Parse filters
+	var filter models.TaskListFilterQuery
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid query parameters",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+	if err := h.validate.Struct(filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	assigneeID, err := parseUserFilter(filter.AssigneeID, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "assignee_id must be a user ID or \"me\"",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+	creatorID, err := parseUserFilter(filter.CreatedBy, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "created_by must be a user ID or \"me\"",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	// Parse pagination parameters
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultTaskLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	orderBy, err := parseSort(c, h.cfg.Pagination, taskSortFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	// Only tasks in projects the caller owns are visible
+	ownedProjects := ownedProjectIDs(db, currentUserID)
+	listedTasks := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("project_id IN (?)", ownedProjects)
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+		if filter.Priority != "" {
+			db = db.Where("priority = ?", filter.Priority)
+		}
+		if assigneeID != nil {
+			db = db.Where("assignee_id = ?", *assigneeID)
+		}
+		if creatorID != nil {
+			db = db.Where("created_by = ?", *creatorID)
+		}
+		return db
+	}
+
+	var tasks []models.Task
+	var total int64
+
+	if err := db.Model(&models.Task{}).Scopes(listedTasks).Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if !pastLastPage(offset, total) {
+		if err := db.Preload("Project").Preload("Assignee").Preload("Creator").
+			Scopes(listedTasks).
+			Order(orderBy).
+			Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch tasks",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Convert to response format
+	taskResponses := make([]models.TaskResponse, len(tasks))
+	for i, task := range tasks {
+		taskResponses[i] = task.ToResponse()
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	return c.JSON(models.ListResponse{
+		Data: taskResponses,
+		Pagination: models.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// GetUserAssignedTasks lists the tasks assigned to a user across the
+// projects the current user owns
+func (h *TaskHandler) GetUserAssignedTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid user ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Parse filters
+	var filter models.AssignedTaskFilterQuery
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid query parameters",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+	if err := h.validate.Struct(filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Parse pagination parameters
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultTaskLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	orderBy, err := parseSort(c, h.cfg.Pagination, taskSortFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	// Only tasks in projects the caller owns are visible
+	ownedProjects := ownedProjectIDs(db, currentUserID)
+	assignedTasks := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("assignee_id = ? AND project_id IN (?)", userID, ownedProjects)
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+		if filter.Priority != "" {
+			db = db.Where("priority = ?", filter.Priority)
+		}
+		return db
+	}
+
+	var tasks []models.Task
+	var total int64
+
+	if err := db.Model(&models.Task{}).Scopes(assignedTasks).Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if !pastLastPage(offset, total) {
+		if err := db.Preload("Project").Preload("Assignee").Preload("Creator").
+			Scopes(assignedTasks).
+			Order(orderBy).
+			Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch tasks",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Convert to response format
+	taskResponses := make([]models.TaskResponse, len(tasks))
+	for i, task := range tasks {
+		taskResponses[i] = task.ToResponse()
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	return c.JSON(models.ListResponse{
+		Data: taskResponses,
+		Pagination: models.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// SearchTasks matches q against the titles and descriptions of tasks in every
+// project the caller owns
+func (h *TaskHandler) SearchTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "q is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Parse pagination parameters
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultTaskLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	orderBy, err := parseSort(c, h.cfg.Pagination, taskSortFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	// Archived tasks are hidden unless explicitly requested. Plain ILIKE
+	// patterns are served by the trigram indexes on title and description;
+	// unaccent matches cannot use them.
+	pattern := "%" + escapeLike(query) + "%"
+	includeArchived := c.QueryBool("include_archived")
+	ownedProjects := ownedProjectIDs(db, currentUserID)
+	matchingTasks := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("project_id IN (?)", ownedProjects).
+			Where(h.search.Contains("title")+" OR "+h.search.Contains("description"), pattern, pattern)
+		if !includeArchived {
+			db = db.Where("archived_at IS NULL")
+		}
+		return db
+	}
+
+	var tasks []models.Task
+	var total int64
+
+	if err := db.Model(&models.Task{}).Scopes(matchingTasks).Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if !pastLastPage(offset, total) {
+		if err := db.Preload("Project").Preload("Assignee").Preload("Creator").
+			Scopes(matchingTasks).
+			Order(orderBy).
+			Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to search tasks",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Convert to response format
+	taskResponses := make([]models.TaskResponse, len(tasks))
+	for i, task := range tasks {
+		taskResponses[i] = task.ToResponse()
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	return c.JSON(models.ListResponse{
+		Data: taskResponses,
+		Pagination: models.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// GetTasksDueToday lists the open tasks across the user's projects that are
+// due today in their timezone, earliest first
+func (h *TaskHandler) GetTasksDueToday(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var projectID *uuid.UUID
+	if param := c.Query("project_id"); param != "" {
+		parsed, err := uuid.Parse(param)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "Invalid project_id",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidQuery,
+			})
+		}
+		projectID = &parsed
+	}
+
+	// Today is bounded by midnights in the user's timezone
+	loc, err := userLocation(c, db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid timezone",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+	today := startOfDay(time.Now(), loc)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	// Parse pagination parameters
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultTaskLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	// Archived tasks are hidden as in other task lists
+	ownedProjects := ownedProjectIDs(db, currentUserID)
+	dueToday := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("project_id IN (?)", ownedProjects).
+			Where("due_date >= ? AND due_date < ?", today, tomorrow).
+			Scopes(models.OpenStatusScope).
+			Where("archived_at IS NULL")
+		if projectID != nil {
+			db = db.Where("project_id = ?", *projectID)
+		}
+		return db
+	}
+
+	var tasks []models.Task
+	var total int64
+
+	if err := db.Model(&models.Task{}).Scopes(dueToday).Count(&total).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if !pastLastPage(offset, total) {
+		if err := db.Preload("Project").Preload("Assignee").Preload("Creator").
+			Scopes(dueToday).
+			Order("due_date, " + stableOrder).
+			Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch tasks",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Convert to response format
+	taskResponses := make([]models.TaskResponse, len(tasks))
+	for i, task := range tasks {
+		taskResponses[i] = task.ToResponse()
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	return c.JSON(models.ListResponse{
+		Data: taskResponses,
+		Pagination: models.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+	})
+}
+
+// GetRecentTasks lists the tasks the current user viewed most recently, newest
+// first. Tasks the user can no longer see are skipped.
+func (h *TaskHandler) GetRecentTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultRecentTaskLimit)))
+	if limit < 1 || limit > config.MaxPageLimit {
+		limit = defaultRecentTaskLimit
+	}
+
+	var views []models.TaskView
+	if err := db.Joins("JOIN tasks ON tasks.id = task_views.task_id AND tasks.deleted_at IS NULL").
+		Where("task_views.user_id = ?", currentUserID).
+		Where("tasks.project_id IN (?)", ownedProjectIDs(db, currentUserID)).
+		Order("task_views.viewed_at DESC").
+		Limit(limit).Find(&views).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch recent tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	taskIDs := make([]uuid.UUID, len(views))
+	for i, view := range views {
+		taskIDs[i] = view.TaskID
+	}
+
+	var tasks []models.Task
+	if err := db.Preload("Project").Preload("Assignee").Preload("Creator").
+		Where("id IN ?", taskIDs).Find(&tasks).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch recent tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	byID := make(map[uuid.UUID]models.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	// Keep the order of the views
+	responses := make([]models.RecentTaskResponse, 0, len(views))
+	for _, view := range views {
+		task, ok := byID[view.TaskID]
+		if !ok {
+			continue
+		}
+		responses = append(responses, models.RecentTaskResponse{
+			TaskResponse: task.ToResponse(),
+			ViewedAt:     view.ViewedAt,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Recent tasks retrieved successfully",
+		Data:    responses,
+	})
+}
+
+// GetTask retrieves a task by ID
+func (h *TaskHandler) GetTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	fields, err := parseFields(c, taskResponseFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+
+	var task models.Task
+	if err := db.Preload("Project").Preload("Assignee").Preload("Creator").
+		Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
+		First(&task).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	h.views.Record(currentUserID, task.ID)
+
+	data, err := selectFields(task.ToResponse(), fields)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to encode task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Task retrieved successfully",
+		Data:    data,
+	})
+}
+
+// GetTaskContext returns the task's project and assignee names for
+// breadcrumbs, reading only those columns instead of preloading both records
+func (h *TaskHandler) GetTaskContext(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Joining the project also checks ownership; deleted assignees are
+	// left out as they are when preloaded
+	var row struct {
+		ProjectID         uuid.UUID
+		ProjectName       string
+		ProjectColor      string
+		AssigneeID        *uuid.UUID
+		AssigneeFirstName *string
+		AssigneeLastName  *string
+	}
+	if err := db.Model(&models.Task{}).
+		Select("projects.id AS project_id, projects.name AS project_name, projects.color AS project_color, "+
+			"users.id AS assignee_id, users.first_name AS assignee_first_name, users.last_name AS assignee_last_name").
+		Joins("JOIN projects ON projects.id = tasks.project_id AND projects.deleted_at IS NULL").
+		Joins("LEFT JOIN users ON users.id = tasks.assignee_id AND users.deleted_at IS NULL").
+		Where("tasks.id = ? AND projects.owner_id = ?", taskID, currentUserID).
+		Take(&row).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task context",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	response := models.TaskContextResponse{
+		TaskID: taskID,
+		Project: models.TaskContextProject{
+			ID:    row.ProjectID,
+			Name:  row.ProjectName,
+			Color: row.ProjectColor,
+		},
+	}
+	if row.AssigneeID != nil {
+		response.Assignee = &models.TaskContextAssignee{
+			ID:        *row.AssigneeID,
+			FirstName: *row.AssigneeFirstName,
+			LastName:  *row.AssigneeLastName,
+		}
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Task context retrieved successfully",
+		Data:    response,
+	})
+}
+
+// UpdateTask updates a task
+func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.TaskUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Validate request
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+	if h.exceedsDueDateHorizon(req.DueDate) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(models.ErrorResponse{
+			Error:     "Unprocessable Entity",
+			Message:   h.dueDateTooFarMessage(),
+			Code:      fiber.StatusUnprocessableEntity,
+			ErrorCode: models.ErrDueDateTooFar,
+		})
+	}
+
+	// Find task and verify ownership
+	var task models.Task
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
+		First(&task).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Update fields
+	previousAssignee := task.AssigneeID
+	if req.Title != "" {
+		task.Title = req.Title
+	}
+	if req.Description != nil {
+		task.Description = req.Description
+	}
+	if req.AssigneeID != nil {
+		task.AssigneeID = req.AssigneeID
+	}
+	if req.Status != nil {
+		task.Status = *req.Status
+	}
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+	if req.DueDate != nil {
+		// A new due date makes the task eligible for escalation and an overdue
+		// notice again
+		if task.DueDate == nil || !task.DueDate.Equal(*req.DueDate) {
+			task.EscalatedAt = nil
+			task.OverdueNotifiedAt = nil
+		}
+		task.DueDate = req.DueDate
+	}
+
+	// An explicit status wins over starting the task automatically
+	autoStarted := false
+	if req.Status == nil {
+		if autoStarted, err = autoStart(db, &task, previousAssignee); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch project settings",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	sendEmails := func() {}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&task).Error; err != nil {
+			return err
+		}
+		if autoStarted {
+			if err := recordAutoStart(c, tx, task.ID, currentUserID, task.AssigneeID); err != nil {
+				return err
+			}
+		}
+		if sameAssignee(previousAssignee, task.AssigneeID) {
+			return nil
+		}
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, []models.Task{task})
+		return err
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to update task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	sendEmails()
+
+	// Load the task with relationships
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&task, task.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	h.broker.Publish(events.NewTaskEvent(assignmentEvent(autoStarted), &task))
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Task updated successfully",
+		Data:    task.ToResponse(),
+	})
+}
+
+// UpdateTaskStatus updates only the status of a task
+func (h *TaskHandler) UpdateTaskStatus(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.TaskStatusUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Validate request
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Find task and verify ownership
+	var task models.Task
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
+		First(&task).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Update status
+	task.Status = req.Status
+
+	if err := db.Save(&task).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to update task status",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Load the task with relationships
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&task, task.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	h.broker.Publish(events.NewTaskEvent(events.TaskUpdated, &task))
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Task status updated successfully",
+		Data:    task.ToResponse(),
+	})
+}
+
+// ReopenTask moves a completed task back to todo and clears its completion time
+func (h *TaskHandler) ReopenTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -59,83 +2614,93 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
-	// Verify project exists and user owns it
-	var project models.Project
-	if err := h.db.Where("id = ? AND owner_id = ?", projectUUID, currentUserID).
-		First(&project).Error; err != nil {
+	// Find task and verify ownership
+	var task models.Task
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
+		First(&task).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "Project not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to verify project",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Create task
-	task := models.Task{
-		Title:     req.Title,
-		ProjectID: projectUUID,
-		Status:    models.TaskStatusTodo,
-		Priority:  models.TaskPriorityMedium,
+	if task.Status != models.TaskStatusDone {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   "Only completed tasks can be reopened",
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrTaskNotDone,
+		})
 	}
 
-	if req.Description != "" {
-		task.Description = &req.Description
-	}
-	if req.AssigneeID != nil {
-		task.AssigneeID = req.AssigneeID
-	}
-	if req.Priority != nil {
-		task.Priority = *req.Priority
-	}
-	if req.DueDate != nil {
-		task.DueDate = req.DueDate
-	}
+	// The BeforeUpdate hook also clears completed_at for non-done statuses
+	completedAt := task.CompletedAt
+	task.Status = models.TaskStatusTodo
+	task.CompletedAt = nil
 
-	if err := h.db.Create(&task).Error; err != nil {
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&task).Error; err != nil {
+			return err
+		}
+		return recordTaskActivity(c, tx, task.ID, &currentUserID, models.TaskActivityReopened, map[string]interface{}{
+			"completed_at": completedAt,
+		})
+	}); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to create task",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to reopen task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
 	// Load the task with relationships
-	if err := h.db.Preload("Project").Preload("Assignee").First(&task, task.ID).Error; err != nil {
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&task, task.ID).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to load task details",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
-		Message: "Task created successfully",
+	h.broker.Publish(events.NewTaskEvent(events.TaskReopened, &task))
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Task reopened successfully",
 		Data:    task.ToResponse(),
 	})
 }
 
-// GetProjectTasks retrieves tasks for a specific project
-func (h *TaskHandler) GetProjectTasks(c *fiber.Ctx) error {
-	projectID := c.Params("project_id")
-	projectUUID, err := uuid.Parse(projectID)
+// CloneTask copies a task into the same project as a fresh todo task
+func (h *TaskHandler) CloneTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	taskID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid project ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -143,94 +2708,162 @@ func (h *TaskHandler) GetProjectTasks(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
-	// Verify project exists and user owns it
-	var project models.Project
-	if err := h.db.Where("id = ? AND owner_id = ?", projectUUID, currentUserID).
-		First(&project).Error; err != nil {
+	// The body is optional
+	var req models.TaskCloneRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "Invalid request body",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidRequestBody,
+			})
+		}
+		if err := h.validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Validation Error",
+				Message:   err.Error(),
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrValidation,
+			})
+		}
+	}
+
+	// Verify the new assignee exists and is active
+	if req.AssigneeID != nil {
+		var assignee models.User
+		if err := db.Where("id = ? AND is_active = ?", *req.AssigneeID, true).
+			First(&assignee).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+					Error:     "Bad Request",
+					Message:   "Assignee not found or inactive",
+					Code:      fiber.StatusBadRequest,
+					ErrorCode: models.ErrAssigneeUnavailable,
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify assignee",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Find task and verify ownership
+	var source models.Task
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
+		First(&source).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "Project not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to verify project",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-
-	if page < 1 {
-		page = 1
+	// Enforce the per-project task limit
+	limitDetails, exceeded, err := h.checkTaskLimit(db, source.ProjectID, 1)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	if exceeded {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   fmt.Sprintf("Projects are limited to %d tasks", limitDetails.Limit),
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrProjectTaskLimit,
+			Details:   limitDetails,
+		})
 	}
 
-	offset := (page - 1) * limit
-
-	var tasks []models.Task
-	var total int64
+	// Copy the task content; progress, snoozing and escalation start over
+	task := models.Task{
+		Title:       source.Title,
+		Description: source.Description,
+		ProjectID:   source.ProjectID,
+		AssigneeID:  source.AssigneeID,
+		CreatedBy:   currentUserID,
+		Status:      models.TaskStatusTodo,
+		Priority:    source.Priority,
+		DueDate:     source.DueDate,
+	}
+	if req.Title != "" {
+		task.Title = req.Title
+	}
+	if req.AssigneeID != nil {
+		task.AssigneeID = req.AssigneeID
+	}
 
-	// Count total tasks for the project
-	if err := h.db.Model(&models.Task{}).Where("project_id = ?", projectUUID).Count(&total).Error; err != nil {
+	var sendEmails func()
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, []models.Task{task})
+		return err
+	}); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to count tasks",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to clone task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
+	sendEmails()
 
-	// Get tasks with pagination
-	if err := h.db.Preload("Project").Preload("Assignee").
-		Where("project_id = ?", projectUUID).
-		Offset(offset).Limit(limit).Find(&tasks).Error; err != nil {
+	// Load the task with relationships
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&task, task.ID).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch tasks",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Convert to response format
-	taskResponses := make([]models.TaskResponse, len(tasks))
-	for i, task := range tasks {
-		taskResponses[i] = task.ToResponse()
-	}
-
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	h.broker.Publish(events.NewTaskEvent(events.TaskCreated, &task))
 
-	return c.JSON(models.ListResponse{
-		Data: taskResponses,
-		Pagination: models.PaginationResponse{
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
+		Message: "Task cloned successfully",
+		Data:    task.ToResponse(),
 	})
 }
 
-// GetTask retrieves a task by ID
-func (h *TaskHandler) GetTask(c *fiber.Ctx) error {
+// UpdateTaskAssignee assigns a task to a user or unassigns it
+func (h *TaskHandler) UpdateTaskAssignee(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	taskID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid task ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -238,46 +2871,134 @@ func (h *TaskHandler) GetTask(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
+	var req models.TaskAssigneeUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// Verify the new assignee exists and is active
+	if req.AssigneeID != nil {
+		var assignee models.User
+		if err := db.Where("id = ? AND is_active = ?", *req.AssigneeID, true).
+			First(&assignee).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+					Error:     "Bad Request",
+					Message:   "Assignee not found or inactive",
+					Code:      fiber.StatusBadRequest,
+					ErrorCode: models.ErrAssigneeUnavailable,
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify assignee",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+	}
+
+	// Find task and verify ownership
 	var task models.Task
-	if err := h.db.Preload("Project").Preload("Assignee").
-		Joins("JOIN projects ON tasks.project_id = projects.id").
-		Where("tasks.id = ? AND projects.owner_id = ?", taskID, currentUserID).
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
 		First(&task).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "Task not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch task",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Update assignee
+	previousAssignee := task.AssigneeID
+	task.AssigneeID = req.AssigneeID
+	autoStarted, err := autoStart(db, &task, previousAssignee)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch project settings",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	sendEmails := func() {}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&task).Error; err != nil {
+			return err
+		}
+		if autoStarted {
+			if err := recordAutoStart(c, tx, task.ID, currentUserID, task.AssigneeID); err != nil {
+				return err
+			}
+		}
+		if sameAssignee(previousAssignee, task.AssigneeID) {
+			return nil
+		}
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, []models.Task{task})
+		return err
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to update task assignee",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
+	sendEmails()
+
+	// Load the task with relationships
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&task, task.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	h.broker.Publish(events.NewTaskEvent(assignmentEvent(autoStarted), &task))
 
 	return c.JSON(models.SuccessResponse{
-		Message: "Task retrieved successfully",
+		Message: "Task assignee updated successfully",
 		Data:    task.ToResponse(),
 	})
 }
 
-// UpdateTask updates a task
-func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
+// SnoozeTask hides a task from project listings until the given time
+func (h *TaskHandler) SnoozeTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	taskID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid task ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -285,92 +3006,99 @@ func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
-	var req models.TaskUpdateRequest
+	var req models.TaskSnoozeRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	// A null snoozed_until clears the snooze
+	if req.SnoozedUntil != nil && !req.SnoozedUntil.After(time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "snoozed_until must be in the future",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
 		})
 	}
 
 	// Find task and verify ownership
 	var task models.Task
-	if err := h.db.Joins("JOIN projects ON tasks.project_id = projects.id").
-		Where("tasks.id = ? AND projects.owner_id = ?", taskID, currentUserID).
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
 		First(&task).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "Task not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch task",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Update fields
-	if req.Title != "" {
-		task.Title = req.Title
-	}
-	if req.Description != nil {
-		task.Description = req.Description
-	}
-	if req.AssigneeID != nil {
-		task.AssigneeID = req.AssigneeID
-	}
-	if req.Status != nil {
-		task.Status = *req.Status
-	}
-	if req.Priority != nil {
-		task.Priority = *req.Priority
-	}
-	if req.DueDate != nil {
-		task.DueDate = req.DueDate
-	}
+	task.SnoozedUntil = req.SnoozedUntil
 
-	if err := h.db.Save(&task).Error; err != nil {
+	if err := db.Save(&task).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to update task",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to snooze task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
 	// Load the task with relationships
-	if err := h.db.Preload("Project").Preload("Assignee").First(&task, task.ID).Error; err != nil {
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&task, task.ID).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to load task details",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
+	h.broker.Publish(events.NewTaskEvent(events.TaskUpdated, &task))
+
+	message := "Task snoozed successfully"
+	if task.SnoozedUntil == nil {
+		message = "Task unsnoozed successfully"
+	}
+
 	return c.JSON(models.SuccessResponse{
-		Message: "Task updated successfully",
+		Message: message,
 		Data:    task.ToResponse(),
 	})
 }
 
-// UpdateTaskStatus updates only the status of a task
-func (h *TaskHandler) UpdateTaskStatus(c *fiber.Ctx) error {
+// ArchiveTask hides a task from the default task lists without deleting it
+func (h *TaskHandler) ArchiveTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	taskID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid task ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -378,84 +3106,99 @@ func (h *TaskHandler) UpdateTaskStatus(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
-		})
-	}
-
-	var req models.TaskStatusUpdateRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
-	// Validate request
-	if err := h.validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Validation Error",
-			Message: err.Error(),
-			Code:    fiber.StatusBadRequest,
-		})
+	// The body is optional; archiving is the default
+	var req models.TaskArchiveRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "Invalid request body",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidRequestBody,
+			})
+		}
 	}
+	archive := req.Archived == nil || *req.Archived
 
 	// Find task and verify ownership
 	var task models.Task
-	if err := h.db.Joins("JOIN projects ON tasks.project_id = projects.id").
-		Where("tasks.id = ? AND projects.owner_id = ?", taskID, currentUserID).
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
 		First(&task).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "Task not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch task",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Update status
-	task.Status = req.Status
+	// Keep the original archive time when archiving twice
+	if !archive {
+		task.ArchivedAt = nil
+	} else if task.ArchivedAt == nil {
+		now := time.Now()
+		task.ArchivedAt = &now
+	}
 
-	if err := h.db.Save(&task).Error; err != nil {
+	if err := db.Save(&task).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to update task status",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to archive task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
 	// Load the task with relationships
-	if err := h.db.Preload("Project").Preload("Assignee").First(&task, task.ID).Error; err != nil {
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&task, task.ID).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to load task details",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
+	h.broker.Publish(events.NewTaskEvent(events.TaskUpdated, &task))
+
+	message := "Task archived successfully"
+	if !archive {
+		message = "Task unarchived successfully"
+	}
+
 	return c.JSON(models.SuccessResponse{
-		Message: "Task status updated successfully",
+		Message: message,
 		Data:    task.ToResponse(),
 	})
 }
 
 // DeleteTask deletes a task
 func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	taskID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid task ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -463,34 +3206,182 @@ func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
-	// Delete task with ownership verification
-	result := h.db.Joins("JOIN projects ON tasks.project_id = projects.id").
-		Where("tasks.id = ? AND projects.owner_id = ?", taskID, currentUserID).
-		Delete(&models.Task{})
-
-	if result.Error != nil {
+	// Find task and verify ownership
+	var task models.Task
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjectIDs(db, currentUserID)).
+		First(&task).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to delete task",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	if result.RowsAffected == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-			Error:   "Not Found",
-			Message: "Task not found",
-			Code:    fiber.StatusNotFound,
+	if err := db.Delete(&task).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to delete task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
+	h.broker.Publish(events.NewTaskEvent(events.TaskDeleted, &task))
+
 	return c.JSON(models.SuccessResponse{
 		Message: "Task deleted successfully",
 	})
 }
+
+// MergeTask merges a duplicate task into a target task. The duplicate's
+// notifications are moved to the target and the duplicate is soft-deleted in
+// one transaction.
+func (h *TaskHandler) MergeTask(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid task ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.TaskMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	if req.TargetID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "target_id is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	if req.TargetID == taskID {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "A task cannot be merged into itself",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Find both tasks and verify ownership
+	ownedProjects := ownedProjectIDs(db, currentUserID)
+
+	var source models.Task
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", taskID, ownedProjects).
+		First(&source).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	var target models.Task
+	if err := db.Where("tasks.id = ? AND tasks.project_id IN (?)", req.TargetID, ownedProjects).
+		First(&target).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "Target task not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrTaskNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch target task",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Notification{}).
+			Where("task_id = ?", source.ID).
+			Update("task_id", target.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&source).Error; err != nil {
+			return err
+		}
+		return recordTaskActivity(c, tx, target.ID, &currentUserID, models.TaskActivityMerged, map[string]interface{}{
+			"source_id":    source.ID,
+			"source_title": source.Title,
+		})
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to merge tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Load the target with relationships
+	if err := primary(db).Preload("Project").Preload("Assignee").Preload("Creator").First(&target, target.ID).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to load task details",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	h.broker.Publish(events.NewTaskMergedEvent(&source, &target))
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Tasks merged successfully",
+		Data:    target.ToResponse(),
+	})
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/validation"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxQueryDepth is how deeply and/or groups may be nested
+	maxQueryDepth = 5
+
+	// maxQueryConditions is how many conditions one filter may contain
+	maxQueryConditions = 50
+)
+
+// queryFieldKind decides which operators a field supports and how its values
+// are parsed
+type queryFieldKind int
+
+const (
+	queryFieldEnum queryFieldKind = iota
+	queryFieldOrderedEnum
+	queryFieldTime
+	queryFieldUUID
+	queryFieldText
+)
+
+type queryField struct {
+	column string
+	kind   queryFieldKind
+	values func(*validation.TaskValues) []string
+	has    func(*validation.TaskValues, string) bool
+}
+
+// queryFields whitelists the task fields a structured filter may reference
+var queryFields = map[string]queryField{
+	"status":       {column: "status", kind: queryFieldEnum, values: (*validation.TaskValues).Statuses, has: (*validation.TaskValues).HasStatus},
+	"priority":     {column: "priority", kind: queryFieldOrderedEnum, values: (*validation.TaskValues).Priorities, has: (*validation.TaskValues).HasPriority},
+	"assignee_id":  {column: "assignee_id", kind: queryFieldUUID},
+	"title":        {column: "title", kind: queryFieldText},
+	"due_date":     {column: "due_date", kind: queryFieldTime},
+	"completed_at": {column: "completed_at", kind: queryFieldTime},
+	"created_at":   {column: "created_at", kind: queryFieldTime},
+	"updated_at":   {column: "updated_at", kind: queryFieldTime},
+	"archived_at":  {column: "archived_at", kind: queryFieldTime},
+}
+
+// queryOperators maps each operator to its SQL and the field kinds it applies to
+var queryOperators = map[string]struct {
+	sql   string
+	kinds []queryFieldKind
+}{
+	"eq":       {sql: "= ?", kinds: []queryFieldKind{queryFieldEnum, queryFieldOrderedEnum, queryFieldTime, queryFieldUUID, queryFieldText}},
+	"ne":       {sql: "<> ?", kinds: []queryFieldKind{queryFieldEnum, queryFieldOrderedEnum, queryFieldTime, queryFieldUUID, queryFieldText}},
+	"in":       {sql: "IN ?", kinds: []queryFieldKind{queryFieldEnum, queryFieldOrderedEnum, queryFieldUUID}},
+	"nin":      {sql: "NOT IN ?", kinds: []queryFieldKind{queryFieldEnum, queryFieldOrderedEnum, queryFieldUUID}},
+	"lt":       {sql: "< ?", kinds: []queryFieldKind{queryFieldOrderedEnum, queryFieldTime}},
+	"lte":      {sql: "<= ?", kinds: []queryFieldKind{queryFieldOrderedEnum, queryFieldTime}},
+	"gt":       {sql: "> ?", kinds: []queryFieldKind{queryFieldOrderedEnum, queryFieldTime}},
+	"gte":      {sql: ">= ?", kinds: []queryFieldKind{queryFieldOrderedEnum, queryFieldTime}},
+	"contains": {sql: "ILIKE ?", kinds: []queryFieldKind{queryFieldText}},
+	"is_null":  {sql: "IS NULL", kinds: []queryFieldKind{queryFieldTime, queryFieldUUID}},
+	"not_null": {sql: "IS NOT NULL", kinds: []queryFieldKind{queryFieldTime, queryFieldUUID}},
+}
+
+// taskQueryBuilder translates a structured filter into a parameterized WHERE
+// clause. Columns and operators come only from the whitelists above; every
+// value is passed as a bind parameter.
+type taskQueryBuilder struct {
+	values     *validation.TaskValues
+	search     *TextSearch
+	conditions int
+}
+
+func (b *taskQueryBuilder) build(filter models.TaskQueryFilter, depth int) (string, []interface{}, error) {
+	if depth > maxQueryDepth {
+		return "", nil, fmt.Errorf("filters may be nested at most %d levels deep", maxQueryDepth)
+	}
+
+	isGroup := len(filter.And) > 0 || len(filter.Or) > 0
+	switch {
+	case len(filter.And) > 0 && len(filter.Or) > 0:
+		return "", nil, fmt.Errorf("a filter group must use either and or or, not both")
+	case isGroup && filter.Field != "":
+		return "", nil, fmt.Errorf("a filter is either a group or a condition, not both")
+	case isGroup:
+		children, joiner := filter.And, " AND "
+		if len(filter.Or) > 0 {
+			children, joiner = filter.Or, " OR "
+		}
+
+		clauses := make([]string, 0, len(children))
+		var args []interface{}
+		for _, child := range children {
+			clause, childArgs, err := b.build(child, depth+1)
+			if err != nil {
+				return "", nil, err
+			}
+			clauses = append(clauses, clause)
+			args = append(args, childArgs...)
+		}
+		return "(" + strings.Join(clauses, joiner) + ")", args, nil
+	default:
+		return b.condition(filter)
+	}
+}
+
+func (b *taskQueryBuilder) condition(filter models.TaskQueryFilter) (string, []interface{}, error) {
+	b.conditions++
+	if b.conditions > maxQueryConditions {
+		return "", nil, fmt.Errorf("filters may contain at most %d conditions", maxQueryConditions)
+	}
+
+	field, ok := queryFields[filter.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown filter field %q", filter.Field)
+	}
+	op, ok := queryOperators[filter.Op]
+	if !ok || !slices.Contains(op.kinds, field.kind) {
+		return "", nil, fmt.Errorf("operator %q is not supported for %s", filter.Op, filter.Field)
+	}
+
+	clause := "tasks." + field.column + " " + op.sql
+	if filter.Op == "contains" {
+		clause = b.search.Contains("tasks." + field.column)
+	}
+	if filter.Op == "is_null" || filter.Op == "not_null" {
+		return clause, nil, nil
+	}
+
+	if filter.Op == "in" || filter.Op == "nin" {
+		items, ok := filter.Value.([]interface{})
+		if !ok || len(items) == 0 {
+			return "", nil, fmt.Errorf("%s %s expects a non-empty array", filter.Field, filter.Op)
+		}
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			value, err := b.parseQueryValue(filter.Field, field, item)
+			if err != nil {
+				return "", nil, err
+			}
+			values[i] = value
+		}
+		return clause, []interface{}{values}, nil
+	}
+
+	value, err := b.parseQueryValue(filter.Field, field, filter.Value)
+	if err != nil {
+		return "", nil, err
+	}
+	if filter.Op == "contains" {
+		value = "%" + escapeLike(value.(string)) + "%"
+	}
+	return clause, []interface{}{value}, nil
+}
+
+// parseQueryValue checks a condition value against its field's type
+func (b *taskQueryBuilder) parseQueryValue(name string, field queryField, raw interface{}) (interface{}, error) {
+	text, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s expects a string value", name)
+	}
+
+	switch field.kind {
+	case queryFieldEnum, queryFieldOrderedEnum:
+		if !field.has(b.values, text) {
+			return nil, fmt.Errorf("%s must be one of %s", name, strings.Join(field.values(b.values), ", "))
+		}
+		return text, nil
+	case queryFieldTime:
+		t, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects an RFC 3339 timestamp", name)
+		}
+		return t, nil
+	case queryFieldUUID:
+		id, err := uuid.Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a user ID", name)
+		}
+		return id, nil
+	default:
+		return text, nil
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in a user-supplied search term
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
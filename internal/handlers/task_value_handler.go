@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/validation"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// taskValueName restricts custom values to identifiers that are safe to
+// interpolate into ALTER TYPE, which does not accept bind parameters
+var taskValueName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,29}$`)
+
+// taskValueKind describes one configurable task enum and its lookup table
+type taskValueKind struct {
+	label    string
+	plural   string
+	table    string
+	enumType string
+	closable bool
+	add      func(*validation.TaskValues, string)
+}
+
+var (
+	taskStatusKind = taskValueKind{
+		label:    "Task status",
+		plural:   "Task statuses",
+		table:    models.TaskStatusesTable,
+		enumType: "task_status",
+		closable: true,
+		add:      (*validation.TaskValues).AddStatus,
+	}
+	taskPriorityKind = taskValueKind{
+		label:    "Task priority",
+		plural:   "Task priorities",
+		table:    models.TaskPrioritiesTable,
+		enumType: "task_priority",
+		add:      (*validation.TaskValues).AddPriority,
+	}
+)
+
+// LoadTaskValues reads the task statuses and priorities from their lookup
+// tables, and again whenever the cached values need reloading
+func LoadTaskValues(db *gorm.DB) (*validation.TaskValues, error) {
+	return validation.LoadTaskValues(func() (statuses, priorities []string, err error) {
+		if err := db.Table(models.TaskStatusesTable).Order("position").Pluck("name", &statuses).Error; err != nil {
+			return nil, nil, err
+		}
+		if err := db.Table(models.TaskPrioritiesTable).Order("position").Pluck("name", &priorities).Error; err != nil {
+			return nil, nil, err
+		}
+		return statuses, priorities, nil
+	})
+}
+
+type TaskValueHandler struct {
+	db       *gorm.DB
+	values   *validation.TaskValues
+	validate *validator.Validate
+}
+
+func NewTaskValueHandler(db *gorm.DB, cfg *config.Config, values *validation.TaskValues) *TaskValueHandler {
+	return &TaskValueHandler{
+		db:       db,
+		values:   values,
+		validate: validation.New(cfg, values),
+	}
+}
+
+// GetTaskStatuses lists the task statuses in workflow order
+func (h *TaskValueHandler) GetTaskStatuses(c *fiber.Ctx) error {
+	return h.list(c, taskStatusKind)
+}
+
+// GetTaskPriorities lists the task priorities from lowest to highest
+func (h *TaskValueHandler) GetTaskPriorities(c *fiber.Ctx) error {
+	return h.list(c, taskPriorityKind)
+}
+
+// CreateTaskStatus adds a custom task status after the built-in ones. Admin only.
+func (h *TaskValueHandler) CreateTaskStatus(c *fiber.Ctx) error {
+	return h.create(c, taskStatusKind)
+}
+
+// CreateTaskPriority adds a custom task priority ranked above the existing
+// ones. Admin only.
+func (h *TaskValueHandler) CreateTaskPriority(c *fiber.Ctx) error {
+	return h.create(c, taskPriorityKind)
+}
+
+func (h *TaskValueHandler) list(c *fiber.Ctx, kind taskValueKind) error {
+	db := h.db.WithContext(c.UserContext())
+
+	var options []models.TaskValueOption
+	if err := db.Table(kind.table).Order("position").Find(&options).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task values",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: kind.plural + " retrieved successfully",
+		Data:    options,
+	})
+}
+
+func (h *TaskValueHandler) create(c *fiber.Ctx, kind taskValueKind) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	admin, err := isAdmin(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify permissions",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if !admin {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:     "Forbidden",
+			Message:   "Only admins can add task values",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrAdminRequired,
+		})
+	}
+
+	var req models.TaskValueCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+	if !taskValueName.MatchString(req.Name) {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "name must start with a lowercase letter and contain only lowercase letters, digits and underscores, up to 30 characters",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+	if req.Closed && !kind.closable {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "closed only applies to task statuses",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Postgres only appends to an enum, so custom values sort after the
+	// existing ones. The value must be committed before rows can use it.
+	if err := db.Exec(fmt.Sprintf("ALTER TYPE %s ADD VALUE IF NOT EXISTS '%s'", kind.enumType, req.Name)).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to add task value",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	columns, values, args := "name, position, built_in", "?, COALESCE(MAX(position), 0) + 1, false", []interface{}{req.Name}
+	if kind.closable {
+		columns, values, args = columns+", closed", values+", ?", append(args, req.Closed)
+	}
+	result := db.Exec(
+		"INSERT INTO "+kind.table+" ("+columns+") "+
+			"SELECT "+values+" FROM "+kind.table+" "+
+			"ON CONFLICT (name) DO NOTHING",
+		args...,
+	)
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to add task value",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+			Error:     "Conflict",
+			Message:   kind.label + " " + req.Name + " already exists",
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrTaskValueExists,
+		})
+	}
+
+	var option models.TaskValueOption
+	if err := db.Table(kind.table).Where("name = ?", req.Name).First(&option).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch task value",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	kind.add(h.values, option.Name)
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
+		Message: kind.label + " created successfully",
+		Data:    option,
+	})
+}
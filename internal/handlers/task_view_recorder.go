@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"taskflow-api/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// taskViewQueueSize bounds how many views may wait to be written
+const taskViewQueueSize = 256
+
+// TaskViewRecorder writes task views in the background so GetTask never waits
+// on the upsert
+type TaskViewRecorder struct {
+	db    *gorm.DB
+	views chan models.TaskView
+}
+
+func NewTaskViewRecorder(db *gorm.DB) *TaskViewRecorder {
+	return &TaskViewRecorder{
+		db:    db,
+		views: make(chan models.TaskView, taskViewQueueSize),
+	}
+}
+
+// Record schedules a view without blocking. The view is dropped when the
+// queue is full; the recent list is a convenience, not an audit log.
+func (r *TaskViewRecorder) Record(userID, taskID uuid.UUID) {
+	select {
+	case r.views <- models.TaskView{UserID: userID, TaskID: taskID, ViewedAt: time.Now()}:
+	default:
+	}
+}
+
+// Run writes queued views until ctx is cancelled
+func (r *TaskViewRecorder) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case view := <-r.views:
+			r.save(ctx, view)
+		}
+	}
+}
+
+func (r *TaskViewRecorder) save(ctx context.Context, view models.TaskView) {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "task_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"viewed_at"}),
+	}).Create(&view).Error
+	if err != nil {
+		log.Printf("⚠️  Failed to record view of task %s: %v", view.TaskID, err)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Unknown user IDs answer 404 and other users' IDs 403, for updates and
+// deletes alike
+func TestUpdateAndDeleteOtherUsers(t *testing.T) {
+	app := testapp.New(t)
+	caller := testutil.CreateUser(t, app.DB, "caller@example.com")
+	other := testutil.CreateUser(t, app.DB, "other@example.com")
+	token := app.Token(t, caller)
+
+	tests := []struct {
+		name      string
+		id        uuid.UUID
+		want      int
+		errorCode models.ErrorCode
+	}{
+		{"nonexistent", uuid.New(), fiber.StatusNotFound, models.ErrUserNotFound},
+		{"other user", other.ID, fiber.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		path := "/api/v1/users/" + tt.id.String()
+		requests := map[string]func() *http.Response{
+			"update": func() *http.Response {
+				return app.Do(t, fiber.MethodPut, path, token, fiber.Map{"first_name": "Changed"})
+			},
+			"delete": func() *http.Response {
+				return app.Do(t, fiber.MethodDelete, path, token, nil)
+			},
+		}
+		for method, send := range requests {
+			t.Run(tt.name+"/"+method, func(t *testing.T) {
+				resp := send()
+				testapp.ExpectStatus(t, resp, tt.want)
+				var body models.ErrorResponse
+				testapp.Decode(t, resp, &body)
+				if tt.errorCode != "" && body.ErrorCode != tt.errorCode {
+					t.Errorf("error_code = %q, want %q", body.ErrorCode, tt.errorCode)
+				}
+			})
+		}
+	}
+
+	// The other user is untouched
+	var stored models.User
+	if err := app.DB.First(&stored, other.ID).Error; err != nil {
+		t.Fatalf("other user is gone: %v", err)
+	}
+	if stored.FirstName != other.FirstName {
+		t.Errorf("other user's first_name = %q, want %q", stored.FirstName, other.FirstName)
+	}
+}
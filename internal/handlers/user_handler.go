@@ -1,58 +1,96 @@
 package handlers
 
 import (
+	"fmt"
+	"io"
+	"log"
 	"math"
-	"strconv"
+	"net/http"
+	"time"
 
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/mailer"
 	"taskflow-api/internal/middleware"
 	"taskflow-api/internal/models"
+	"taskflow-api/internal/storage"
+	"taskflow-api/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// avatarContentTypes maps the accepted avatar image types to file extensions
+var avatarContentTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+}
+
 type UserHandler struct {
 	db       *gorm.DB
+	cfg      *config.Config
+	storage  storage.Storage
+	mail     *mailer.Queue
 	validate *validator.Validate
 }
 
-func NewUserHandler(db *gorm.DB) *UserHandler {
+func NewUserHandler(db *gorm.DB, cfg *config.Config, store storage.Storage, mail *mailer.Queue) *UserHandler {
 	return &UserHandler{
 		db:       db,
+		cfg:      cfg,
+		storage:  store,
+		mail:     mail,
 		validate: validator.New(),
 	}
 }
 
 // CreateUser creates a new user
 func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	var req models.UserCreateRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
 		})
 	}
+	req.Email = models.NormalizeEmail(req.Email)
 
 	// Validate request
 	if err := h.validate.Struct(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Validation Error",
-			Message: err.Error(),
-			Code:    fiber.StatusBadRequest,
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	// Enforce password policy
+	if err := validation.CheckPassword(h.cfg.Password, req.Password); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
 		})
 	}
 
 	// Check if user already exists
 	var existingUser models.User
-	if err := h.db.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
+	if err := db.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
 		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
-			Error:   "Conflict",
-			Message: "User with this email already exists",
-			Code:    fiber.StatusConflict,
+			Error:     "Conflict",
+			Message:   "User with this email already exists",
+			Code:      fiber.StatusConflict,
+			ErrorCode: models.ErrEmailTaken,
 		})
 	}
 
@@ -60,9 +98,10 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to hash password",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to hash password",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
@@ -79,14 +118,30 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		user.AvatarURL = &req.AvatarURL
 	}
 
-	if err := h.db.Create(&user).Error; err != nil {
+	if req.Timezone != "" {
+		user.Timezone = req.Timezone
+	}
+
+	if err := db.Create(&user).Error; err != nil {
+		// Another registration for the same email won the race
+		if isUniqueViolation(err) {
+			return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse{
+				Error:     "Conflict",
+				Message:   "User with this email already exists",
+				Code:      fiber.StatusConflict,
+				ErrorCode: models.ErrEmailTaken,
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to create user",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to create user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
+	RecordAuthEvent(c, db, h.cfg, models.AuthEventRegistered, &user.ID, user.Email)
+
 	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse{
 		Message: "User created successfully",
 		Data:    user.ToResponse(),
@@ -95,38 +150,52 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 
 // GetUsers retrieves users with pagination
 func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	db := h.db.WithContext(c.UserContext())
 
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	// Parse pagination parameters
+	page, limit, offset, err := parsePagination(c, h.cfg.Pagination, h.cfg.Pagination.DefaultUserLimit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
 	}
 
-	offset := (page - 1) * limit
+	orderBy, err := parseSort(c, h.cfg.Pagination, userSortFields)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
 
 	var users []models.User
 	var total int64
 
 	// Count total users
-	if err := h.db.Model(&models.User{}).Count(&total).Error; err != nil {
+	if err := db.Model(&models.User{}).Count(&total).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to count users",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to count users",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
 	// Get users with pagination
-	if err := h.db.Offset(offset).Limit(limit).Find(&users).Error; err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch users",
-			Code:    fiber.StatusInternalServerError,
-		})
+	if !pastLastPage(offset, total) {
+		if err := db.Order(orderBy).Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch users",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
 	}
 
 	// Convert to response format
@@ -150,29 +219,34 @@ func (h *UserHandler) GetUsers(c *fiber.Ctx) error {
 
 // GetUser retrieves a user by ID
 func (h *UserHandler) GetUser(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid user ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid user ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
 	var user models.User
-	if err := h.db.First(&user, userID).Error; err != nil {
+	if err := db.First(&user, userID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "User not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "User not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrUserNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch user",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
@@ -182,15 +256,96 @@ func (h *UserHandler) GetUser(c *fiber.Ctx) error {
 	})
 }
 
+// GetMySummary returns the profile counts of the current user: projects they
+// own, tasks in those projects, and tasks assigned to them. Weeks start on
+// Monday in the user's timezone.
+func (h *UserHandler) GetMySummary(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	loc, err := userLocation(c, db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid timezone",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidQuery,
+		})
+	}
+	today := startOfDay(time.Now(), loc)
+	weekStart := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+
+	var summary models.UserSummaryResponse
+	if err := db.Model(&models.Project{}).
+		Where("owner_id = ?", currentUserID).
+		Count(&summary.Projects).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count projects",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	if err := db.Model(&models.Task{}).
+		Where("project_id IN (?)", ownedProjectIDs(db, currentUserID)).
+		Count(&summary.Tasks).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Count the tasks assigned to the user in one pass
+	var assigned models.UserAssignedTaskCounts
+	if err := db.Model(&models.Task{}).
+		Select(
+			"COUNT(*) AS total, "+
+				"COUNT(*) FILTER (WHERE completed_at >= ?) AS completed_this_week, "+
+				"COUNT(*) FILTER (WHERE due_date < ? AND "+models.OpenStatusCondition+") AS overdue",
+			weekStart, today,
+		).
+		Where("assignee_id = ?", currentUserID).
+		Scan(&assigned).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to count assigned tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	summary.AssignedTasks = assigned
+
+	return c.JSON(models.SuccessResponse{
+		Message: "User summary retrieved successfully",
+		Data:    summary,
+	})
+}
+
 // UpdateUser updates a user by ID
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid user ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid user ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -198,43 +353,59 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Look the user up first so unknown IDs get 404 and only existing
+	// users get 403
+	var user models.User
+	if err := db.First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "User not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrUserNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
 	if currentUserID != userID {
 		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
-			Error:   "Forbidden",
-			Message: "You can only update your own profile",
-			Code:    fiber.StatusForbidden,
+			Error:     "Forbidden",
+			Message:   "You can only update your own profile",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrForbidden,
 		})
 	}
 
 	var req models.UserUpdateRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid request body",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
 		})
 	}
 
-	// Find user
-	var user models.User
-	if err := h.db.First(&user, userID).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "User not found",
-				Code:    fiber.StatusNotFound,
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to fetch user",
-			Code:    fiber.StatusInternalServerError,
+	// Validate request
+	if err := h.validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   err.Error(),
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
 		})
 	}
 
@@ -251,12 +422,19 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	if req.IsActive != nil {
 		user.IsActive = *req.IsActive
 	}
+	if req.Timezone != nil {
+		user.Timezone = *req.Timezone
+	}
+	if req.EmailNotifications != nil {
+		user.EmailNotifications = *req.EmailNotifications
+	}
 
-	if err := h.db.Save(&user).Error; err != nil {
+	if err := db.Save(&user).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to update user",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to update user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
@@ -268,13 +446,16 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 
 // DeleteUser soft deletes a user by ID
 func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
 	id := c.Params("id")
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-			Error:   "Bad Request",
-			Message: "Invalid user ID",
-			Code:    fiber.StatusBadRequest,
+			Error:     "Bad Request",
+			Message:   "Invalid user ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
 		})
 	}
 
@@ -282,37 +463,476 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	currentUserID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "User not authenticated",
-			Code:    fiber.StatusUnauthorized,
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
 		})
 	}
 
-	if currentUserID != userID {
-		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
-			Error:   "Forbidden",
-			Message: "You can only delete your own profile",
-			Code:    fiber.StatusForbidden,
+	// Unknown IDs get 404 rather than 403, as in UpdateUser
+	var user models.User
+	if err := db.Select("id", "email").First(&user, userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "User not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrUserNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
-	// Soft delete user
-	if err := h.db.Delete(&models.User{}, userID).Error; err != nil {
+	if currentUserID != userID {
+		// Admins may delete other accounts without a password confirmation
+		admin, err := isAdmin(db, currentUserID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify permissions",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+		if !admin {
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+				Error:     "Forbidden",
+				Message:   "You can only delete your own profile",
+				Code:      fiber.StatusForbidden,
+				ErrorCode: models.ErrForbidden,
+			})
+		}
+	} else if h.cfg.Users.DeleteRequiresPassword {
+		// Self-deletion must be confirmed with the current password so an
+		// account can't be removed by a stray or forged request
+		var req models.UserDeleteRequest
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+					Error:     "Bad Request",
+					Message:   "Invalid request body",
+					Code:      fiber.StatusBadRequest,
+					ErrorCode: models.ErrInvalidRequestBody,
+				})
+			}
+		}
+
+		ok, err := h.passwordMatches(db, userID, req.Password)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+					Error:     "Not Found",
+					Message:   "User not found",
+					Code:      fiber.StatusNotFound,
+					ErrorCode: models.ErrUserNotFound,
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify password",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:     "Unauthorized",
+				Message:   "Password confirmation failed",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrInvalidCredentials,
+			})
+		}
+	}
+
+	// Soft delete user and release their assigned tasks in one transaction
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := h.releaseAssignedTasks(tx, userID); err != nil {
+			return err
+		}
+		return tx.Delete(&models.User{}, userID).Error
+	}); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
-				Error:   "Not Found",
-				Message: "User not found",
-				Code:    fiber.StatusNotFound,
+				Error:     "Not Found",
+				Message:   "User not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrUserNotFound,
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to delete user",
-			Code:    fiber.StatusInternalServerError,
+			Error:     "Internal Server Error",
+			Message:   "Failed to delete user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
 		})
 	}
 
+	RecordAuthEvent(c, db, h.cfg, models.AuthEventAccountDeleted, &user.ID, user.Email)
+
 	return c.JSON(models.SuccessResponse{
 		Message: "User deleted successfully",
 	})
 }
+
+// passwordMatches reports whether password matches the user's stored hash
+func (h *UserHandler) passwordMatches(db *gorm.DB, userID uuid.UUID, password string) (bool, error) {
+	if password == "" {
+		return false, nil
+	}
+
+	var user models.User
+	if err := db.Select("id", "password_hash").First(&user, userID).Error; err != nil {
+		return false, err
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil, nil
+}
+
+// releaseAssignedTasks detaches a user's assigned tasks according to the
+// configured policy, either clearing the assignee or handing the task to the
+// project owner (falling back to unassigned when the user owns the project).
+// Soft-deleted tasks are included so none can resurface with the old assignee.
+func (h *UserHandler) releaseAssignedTasks(tx *gorm.DB, userID uuid.UUID) error {
+	var assignee interface{}
+	if h.cfg.Users.DeletedAssigneePolicy == config.DeletedAssigneeReassignToOwner {
+		assignee = gorm.Expr("NULLIF((SELECT owner_id FROM projects WHERE projects.id = tasks.project_id), ?)", userID)
+	}
+
+	return tx.Unscoped().Model(&models.Task{}).
+		Where("assignee_id = ?", userID).
+		Update("assignee_id", assignee).Error
+}
+
+// ReassignTasks moves every task assigned to a user over to another user.
+// Admins reassign across all projects, other users only within projects they own.
+func (h *UserHandler) ReassignTasks(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	id := c.Params("id")
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid user ID",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidID,
+		})
+	}
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	var req models.UserReassignTasksRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid request body",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+
+	if req.NewAssigneeID == uuid.Nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "new_assignee_id is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	if req.NewAssigneeID == userID {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "New assignee must be a different user",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrSameAssignee,
+		})
+	}
+
+	// Verify the new assignee exists and is active
+	var newAssignee models.User
+	if err := db.Where("id = ? AND is_active = ?", req.NewAssigneeID, true).
+		First(&newAssignee).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "New assignee not found or inactive",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrAssigneeUnavailable,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify new assignee",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	admin, err := isAdmin(db, currentUserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to verify permissions",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	var reassigned int64
+	sendEmails := func() {}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("assignee_id = ?", userID)
+		if !admin {
+			query = query.Where("project_id IN (?)", ownedProjectIDs(tx, currentUserID))
+		}
+		var tasks []models.Task
+		if err := query.Find(&tasks).Error; err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+
+		taskIDs := make([]uuid.UUID, len(tasks))
+		for i := range tasks {
+			taskIDs[i] = tasks[i].ID
+			tasks[i].AssigneeID = &newAssignee.ID
+		}
+		result := tx.Model(&models.Task{}).Where("id IN ?", taskIDs).Update("assignee_id", newAssignee.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		reassigned = result.RowsAffected
+
+		var err error
+		sendEmails, err = notifyAssigned(tx, h.mail, currentUserID, tasks)
+		return err
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to reassign tasks",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+	sendEmails()
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Tasks reassigned successfully",
+		Data: fiber.Map{
+			"reassigned": reassigned,
+		},
+	})
+}
+
+// UploadAvatar stores an uploaded image as the current user's avatar
+func (h *UserHandler) UploadAvatar(c *fiber.Ctx) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Validation Error",
+			Message:   "avatar file is required",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrValidation,
+		})
+	}
+
+	if fileHeader.Size > int64(h.cfg.Storage.AvatarMaxSize) {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(models.ErrorResponse{
+			Error:     "Request Entity Too Large",
+			Message:   fmt.Sprintf("Avatar must be at most %d bytes", h.cfg.Storage.AvatarMaxSize),
+			Code:      fiber.StatusRequestEntityTooLarge,
+			ErrorCode: models.ErrRequestTooLarge,
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+			Error:     "Bad Request",
+			Message:   "Invalid avatar file",
+			Code:      fiber.StatusBadRequest,
+			ErrorCode: models.ErrInvalidRequestBody,
+		})
+	}
+	defer file.Close()
+
+	// Detect the type from the content rather than trusting the client
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	ext, ok := avatarContentTypes[http.DetectContentType(head[:n])]
+	if !ok {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(models.ErrorResponse{
+			Error:     "Unsupported Media Type",
+			Message:   "Avatar must be a PNG, JPEG or WebP image",
+			Code:      fiber.StatusUnsupportedMediaType,
+			ErrorCode: models.ErrUnsupportedMedia,
+		})
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to read avatar",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// Find user
+	var user models.User
+	if err := db.First(&user, currentUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "User not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrUserNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// A new key per upload avoids serving a stale cached image
+	key := fmt.Sprintf("avatars/%s-%d%s", user.ID, time.Now().UnixNano(), ext)
+	url, err := h.storage.Save(key, file)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to store avatar",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	previous := user.AvatarURL
+	user.AvatarURL = &url
+
+	if err := db.Save(&user).Error; err != nil {
+		h.deleteAvatar(url)
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to update user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	// The old file is no longer referenced
+	if previous != nil {
+		h.deleteAvatar(*previous)
+	}
+
+	return c.JSON(models.SuccessResponse{
+		Message: "Avatar updated successfully",
+		Data:    user.ToResponse(),
+	})
+}
+
+// deleteAvatar removes an avatar file; a failure only leaves an orphaned file
+// behind, so it is logged rather than returned
+func (h *UserHandler) deleteAvatar(url string) {
+	if err := h.storage.Delete(url); err != nil {
+		log.Printf("⚠️  Failed to delete avatar %s: %v", url, err)
+	}
+}
+
+// DeactivateUser marks the current user's account as inactive without deleting it
+func (h *UserHandler) DeactivateUser(c *fiber.Ctx) error {
+	return h.setUserActive(c, false)
+}
+
+// ReactivateUser restores a previously deactivated account for the current user
+func (h *UserHandler) ReactivateUser(c *fiber.Ctx) error {
+	return h.setUserActive(c, true)
+}
+
+func (h *UserHandler) setUserActive(c *fiber.Ctx, active bool) error {
+	db := h.db.WithContext(c.UserContext())
+
+	// Get current user
+	currentUserID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+			Error:     "Unauthorized",
+			Message:   "User not authenticated",
+			Code:      fiber.StatusUnauthorized,
+			ErrorCode: models.ErrUnauthenticated,
+		})
+	}
+
+	// Find user
+	var user models.User
+	if err := db.First(&user, currentUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+				Error:     "Not Found",
+				Message:   "User not found",
+				Code:      fiber.StatusNotFound,
+				ErrorCode: models.ErrUserNotFound,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to fetch user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	action, event := "deactivate", models.AuthEventAccountDeactivated
+	if active {
+		action, event = "reactivate", models.AuthEventAccountReactivated
+	}
+
+	user.IsActive = active
+
+	if err := db.Save(&user).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+			Error:     "Internal Server Error",
+			Message:   "Failed to " + action + " user",
+			Code:      fiber.StatusInternalServerError,
+			ErrorCode: models.ErrInternal,
+		})
+	}
+
+	RecordAuthEvent(c, db, h.cfg, event, &user.ID, user.Email)
+
+	return c.JSON(models.SuccessResponse{
+		Message: "User " + action + "d successfully",
+		Data:    user.ToResponse(),
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"testing"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+
+	"github.com/google/uuid"
+)
+
+func TestReleaseAssignedTasks(t *testing.T) {
+	policies := map[string]bool{
+		config.DeletedAssigneeUnassign:        false,
+		config.DeletedAssigneeReassignToOwner: true,
+	}
+	for policy, toOwner := range policies {
+		t.Run(policy, func(t *testing.T) {
+			db := testutil.OpenDB(t)
+			owner := testutil.CreateUser(t, db, "owner@example.com")
+			deleted := testutil.CreateUser(t, db, "deleted@example.com")
+
+			project := testutil.CreateProject(t, db, owner.ID, "Shared")
+			ownProject := testutil.CreateProject(t, db, deleted.ID, "Own")
+			open := testutil.CreateTask(t, db, project, "Open", &deleted.ID)
+			trashed := testutil.CreateTask(t, db, project, "Trashed", &deleted.ID)
+			own := testutil.CreateTask(t, db, ownProject, "Own", &deleted.ID)
+			if err := db.Delete(&trashed).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := &config.Config{Users: config.UserConfig{DeletedAssigneePolicy: policy}}
+			h := NewUserHandler(db, cfg, nil, nil)
+			if err := h.releaseAssignedTasks(db, deleted.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			// No task, deleted or not, may still reference the user
+			var remaining int64
+			if err := db.Unscoped().Model(&models.Task{}).Where("assignee_id = ?", deleted.ID).
+				Count(&remaining).Error; err != nil {
+				t.Fatal(err)
+			}
+			if remaining != 0 {
+				t.Errorf("%d tasks still reference the deleted assignee", remaining)
+			}
+
+			var want *uuid.UUID
+			if toOwner {
+				want = &owner.ID
+			}
+			for _, task := range []models.Task{open, trashed} {
+				assertAssignee(t, db, task.ID, want)
+			}
+			// Tasks in the deleted user's own projects always end up unassigned
+			assertAssignee(t, db, own.ID, nil)
+		})
+	}
+}
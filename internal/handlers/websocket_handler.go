@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"taskflow-api/internal/events"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// wsSendBuffer is how many outgoing messages may queue for a client
+	// before it is considered too slow and disconnected
+	wsSendBuffer = 128
+
+	// wsWriteTimeout bounds a single write to the client
+	wsWriteTimeout = 10 * time.Second
+
+	// wsPingInterval is how often the server pings an idle client
+	wsPingInterval = 30 * time.Second
+)
+
+// Client actions and server message types for the websocket protocol
+const (
+	wsActionSubscribe   = "subscribe"
+	wsActionUnsubscribe = "unsubscribe"
+
+	wsTypeSubscribed   = "subscribed"
+	wsTypeUnsubscribed = "unsubscribed"
+	wsTypeEvent        = "event"
+	wsTypeError        = "error"
+)
+
+type wsRequest struct {
+	Action    string    `json:"action"`
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+type wsMessage struct {
+	Type      string        `json:"type"`
+	ProjectID *uuid.UUID    `json:"project_id,omitempty"`
+	Message   string        `json:"message,omitempty"`
+	Event     *events.Event `json:"event,omitempty"`
+}
+
+type wsSubscription struct {
+	unsubscribe func()
+}
+
+type WebSocketHandler struct {
+	db     *gorm.DB
+	broker *events.Broker
+}
+
+func NewWebSocketHandler(db *gorm.DB, broker *events.Broker) *WebSocketHandler {
+	return &WebSocketHandler{
+		db:     db,
+		broker: broker,
+	}
+}
+
+// HandleConnection lets an authenticated client subscribe to task events of
+// the projects it owns. Clients send {"action":"subscribe","project_id":...}
+// or "unsubscribe" and receive "event" messages for each task change.
+func (h *WebSocketHandler) HandleConnection(conn *websocket.Conn) {
+	userID, ok := conn.Locals("user_id").(uuid.UUID)
+	if !ok {
+		conn.Close()
+		return
+	}
+
+	out := make(chan wsMessage, wsSendBuffer)
+	done := make(chan struct{})
+
+	var mu sync.Mutex
+	subscriptions := make(map[uuid.UUID]*wsSubscription)
+
+	defer func() {
+		close(done)
+		mu.Lock()
+		for _, sub := range subscriptions {
+			sub.unsubscribe()
+		}
+		mu.Unlock()
+		conn.Close()
+	}()
+
+	// send queues a message without blocking; a client that cannot keep up
+	// is disconnected rather than slowing down event delivery
+	send := func(msg wsMessage) {
+		select {
+		case out <- msg:
+		case <-done:
+		default:
+			conn.Close()
+		}
+	}
+
+	// Single writer so messages and pings never interleave
+	go func() {
+		ticker := time.NewTicker(wsPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case msg := <-out:
+				conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+				if err := conn.WriteJSON(msg); err != nil {
+					conn.Close()
+					return
+				}
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+					conn.Close()
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req wsRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			send(wsMessage{Type: wsTypeError, Message: "Invalid message"})
+			continue
+		}
+
+		projectID := req.ProjectID
+		switch req.Action {
+		case wsActionSubscribe:
+			mu.Lock()
+			_, subscribed := subscriptions[projectID]
+			mu.Unlock()
+			if subscribed {
+				send(wsMessage{Type: wsTypeSubscribed, ProjectID: &projectID})
+				continue
+			}
+
+			// Only the project owner may follow its tasks
+			var project models.Project
+			if err := h.db.Where("id = ? AND owner_id = ?", projectID, userID).
+				First(&project).Error; err != nil {
+				message := "Failed to verify project"
+				if err == gorm.ErrRecordNotFound {
+					message = "Project not found"
+				}
+				send(wsMessage{Type: wsTypeError, ProjectID: &projectID, Message: message})
+				continue
+			}
+
+			stream, unsubscribe := h.broker.Subscribe(project.ID)
+			sub := &wsSubscription{unsubscribe: unsubscribe}
+			mu.Lock()
+			subscriptions[project.ID] = sub
+			mu.Unlock()
+			send(wsMessage{Type: wsTypeSubscribed, ProjectID: &projectID})
+
+			go func() {
+				for event := range stream {
+					send(wsMessage{Type: wsTypeEvent, ProjectID: &projectID, Event: &event})
+
+					// Ownership was only checked on subscribing; drop the
+					// subscription once the user no longer owns the project
+					if event.IsOwnershipChange() {
+						if owned, err := ownsProject(primary(h.db), userID, projectID); err != nil || !owned {
+							sub.unsubscribe()
+						}
+					}
+				}
+
+				// The stream closes on unsubscribe or when the broker drops a
+				// subscriber that fell behind; the client should resubscribe
+				// and refetch in the latter case
+				mu.Lock()
+				if subscriptions[projectID] == sub {
+					delete(subscriptions, projectID)
+				}
+				mu.Unlock()
+				send(wsMessage{Type: wsTypeUnsubscribed, ProjectID: &projectID})
+			}()
+
+		case wsActionUnsubscribe:
+			mu.Lock()
+			sub, subscribed := subscriptions[projectID]
+			mu.Unlock()
+			if !subscribed {
+				send(wsMessage{Type: wsTypeUnsubscribed, ProjectID: &projectID})
+				continue
+			}
+			sub.unsubscribe()
+
+		default:
+			send(wsMessage{Type: wsTypeError, Message: "Unknown action"})
+		}
+	}
+}
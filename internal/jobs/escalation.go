@@ -0,0 +1,98 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"taskflow-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RunEscalation escalates overdue tasks every interval until ctx is cancelled.
+// Assignees are emailed by RunOverdueNotices.
+func RunEscalation(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			escalated, err := EscalateOverdueTasks(ctx, db)
+			if err != nil {
+				log.Printf("⚠️  Failed to escalate overdue tasks: %v", err)
+				continue
+			}
+			if len(escalated) > 0 {
+				log.Printf("⏫ Escalated %d overdue task(s)", len(escalated))
+			}
+		}
+	}
+}
+
+// escalatablePriorities are the priorities escalation raises; custom
+// priorities already rank above urgent
+var escalatablePriorities = []models.TaskPriority{models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh}
+
+// EscalateOverdueTasks raises the priority of open, overdue tasks by one level
+// in projects that opted in, recording each escalation in the task's activity
+// log. Each task is escalated once per due date; escalated_at is cleared when
+// the due date changes. It returns the escalated tasks.
+func EscalateOverdueTasks(ctx context.Context, db *gorm.DB) ([]models.Task, error) {
+	now := time.Now()
+
+	var escalated []models.Task
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&escalated).Clauses(clause.Returning{}).
+			Where("due_date < ? AND escalated_at IS NULL", now).
+			Scopes(models.OpenStatusScope).
+			Where("priority IN ?", escalatablePriorities).
+			Where("project_id IN (?)", tx.Model(&models.Project{}).Select("id").Where("auto_escalate_overdue = ?", true)).
+			Updates(map[string]interface{}{
+				"priority": gorm.Expr(
+					"CASE priority WHEN ? THEN ? WHEN ? THEN ? ELSE ? END::task_priority",
+					models.TaskPriorityLow, models.EscalatedPriority(models.TaskPriorityLow),
+					models.TaskPriorityMedium, models.EscalatedPriority(models.TaskPriorityMedium),
+					models.TaskPriorityUrgent,
+				),
+				"escalated_at": now,
+			}).Error; err != nil {
+			return err
+		}
+		if len(escalated) == 0 {
+			return nil
+		}
+
+		activities := make([]models.TaskActivity, len(escalated))
+		for i, task := range escalated {
+			activity, err := models.NewTaskActivity(task.ID, nil, models.TaskActivityEscalated, map[string]interface{}{
+				"from":     previousPriority(task.Priority),
+				"to":       task.Priority,
+				"due_date": task.DueDate,
+			})
+			if err != nil {
+				return err
+			}
+			activities[i] = activity
+		}
+		return tx.Create(&activities).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return escalated, nil
+}
+
+// previousPriority is the priority an escalated task had before
+func previousPriority(escalated models.TaskPriority) models.TaskPriority {
+	for _, priority := range escalatablePriorities {
+		if models.EscalatedPriority(priority) == escalated {
+			return priority
+		}
+	}
+	return escalated
+}
@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+)
+
+func TestEscalateOverdueTasksRecordsActivity(t *testing.T) {
+	db := testutil.OpenDB(t)
+	owner := testutil.CreateUser(t, db, "owner@example.com")
+	project := testutil.CreateProject(t, db, owner.ID, "Escalating")
+	if err := db.Model(&project).Update("auto_escalate_overdue", true).Error; err != nil {
+		t.Fatal(err)
+	}
+	overdue := testutil.CreateTask(t, db, project, "Overdue", nil)
+	if err := db.Model(&overdue).Update("due_date", time.Now().Add(-time.Hour)).Error; err != nil {
+		t.Fatal(err)
+	}
+	testutil.CreateTask(t, db, project, "Not due", nil)
+
+	escalated, err := EscalateOverdueTasks(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(escalated) != 1 || escalated[0].ID != overdue.ID {
+		t.Fatalf("escalated %d tasks, want only %s", len(escalated), overdue.ID)
+	}
+
+	var activities []models.TaskActivity
+	if err := db.Where("task_id = ?", overdue.ID).Find(&activities).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(activities) != 1 {
+		t.Fatalf("got %d activities, want 1", len(activities))
+	}
+	activity := activities[0]
+	if activity.Action != models.TaskActivityEscalated || activity.ActorID != nil {
+		t.Errorf("activity = %s by %v, want %s by the system", activity.Action, activity.ActorID, models.TaskActivityEscalated)
+	}
+	var payload struct {
+		From models.TaskPriority `json:"from"`
+		To   models.TaskPriority `json:"to"`
+	}
+	if err := json.Unmarshal(activity.Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.From != models.TaskPriorityMedium || payload.To != models.TaskPriorityHigh {
+		t.Errorf("payload = %+v, want medium to high", payload)
+	}
+
+	// A second run escalates nothing and records nothing
+	if escalated, err := EscalateOverdueTasks(context.Background(), db); err != nil || len(escalated) != 0 {
+		t.Fatalf("second run escalated %d tasks (err %v)", len(escalated), err)
+	}
+}
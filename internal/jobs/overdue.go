@@ -0,0 +1,107 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	"taskflow-api/internal/mailer"
+	"taskflow-api/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RunOverdueNotices emails the assignees of newly overdue tasks every interval
+// until ctx is cancelled
+func RunOverdueNotices(ctx context.Context, db *gorm.DB, mail *mailer.Queue, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := SendOverdueNotices(ctx, db, mail)
+			if err != nil {
+				log.Printf("⚠️  Failed to email overdue task assignees: %v", err)
+				continue
+			}
+			if sent > 0 {
+				log.Printf("⏰ Emailed %d overdue task notice(s)", sent)
+			}
+		}
+	}
+}
+
+// SendOverdueNotices marks open, assigned tasks that are past their due date
+// as notified and queues an overdue email for each assignee, skipping inactive
+// users and those who opted out. It covers every priority and project; each
+// task is noticed once per due date. It returns how many emails were queued.
+func SendOverdueNotices(ctx context.Context, db *gorm.DB, mail *mailer.Queue) (int, error) {
+	now := time.Now()
+
+	var overdue []models.Task
+	if err := db.WithContext(ctx).Model(&overdue).Clauses(clause.Returning{}).
+		Where("due_date < ? AND assignee_id IS NOT NULL AND overdue_notified_at IS NULL", now).
+		Scopes(models.OpenStatusScope).
+		Update("overdue_notified_at", now).Error; err != nil {
+		return 0, err
+	}
+	if len(overdue) == 0 {
+		return 0, nil
+	}
+
+	emails, err := overdueEmails(ctx, db, overdue)
+	if err != nil {
+		return 0, err
+	}
+	for _, msg := range emails {
+		mail.Enqueue(msg)
+	}
+	return len(emails), nil
+}
+
+// overdueEmails builds the overdue email for the assignee of each task
+func overdueEmails(ctx context.Context, db *gorm.DB, overdue []models.Task) ([]mailer.Message, error) {
+	db = db.WithContext(ctx)
+
+	ids := make([]uuid.UUID, len(overdue))
+	assigneeIDs := make([]uuid.UUID, len(overdue))
+	for i, task := range overdue {
+		ids[i] = task.ID
+		assigneeIDs[i] = *task.AssigneeID
+	}
+
+	// Users without a preferences row get the defaults, which opt in
+	var optedOut []uuid.UUID
+	if err := db.Model(&models.NotificationPreference{}).
+		Where("user_id IN ? AND task_overdue = ?", assigneeIDs, false).
+		Pluck("user_id", &optedOut).Error; err != nil {
+		return nil, err
+	}
+
+	var tasks []models.Task
+	if err := db.Preload("Project").Preload("Assignee").
+		Where("id IN ?", ids).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	var emails []mailer.Message
+	for i := range tasks {
+		assignee := tasks[i].Assignee
+		if assignee == nil || !assignee.IsActive || !assignee.EmailNotifications ||
+			slices.Contains(optedOut, assignee.ID) {
+			continue
+		}
+		msg, err := mailer.TaskOverdueEmail(assignee, &tasks[i])
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, msg)
+	}
+	return emails, nil
+}
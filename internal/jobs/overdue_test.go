@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/mailer"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+)
+
+func TestSendOverdueNoticesWithoutEscalation(t *testing.T) {
+	db := testutil.OpenDB(t)
+	owner := testutil.CreateUser(t, db, "owner@example.com")
+	assignee := testutil.CreateUser(t, db, "assignee@example.com")
+	optedOut := testutil.CreateUser(t, db, "opted-out@example.com")
+	pref := models.DefaultNotificationPreference(optedOut.ID)
+	pref.TaskOverdue = false
+	if err := db.Create(&pref).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// The project does not escalate, and urgent tasks are never escalated
+	project := testutil.CreateProject(t, db, owner.ID, "Not escalating")
+	urgent := testutil.CreateTask(t, db, project, "Urgent", &assignee.ID)
+	ignored := testutil.CreateTask(t, db, project, "Ignored", &optedOut.ID)
+	testutil.CreateTask(t, db, project, "Not due", &assignee.ID)
+	if err := db.Model(&urgent).Update("priority", models.TaskPriorityUrgent).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, task := range []models.Task{urgent, ignored} {
+		if err := db.Model(&task).Update("due_date", time.Now().Add(-time.Hour)).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mail := mailer.NewQueue(mailer.LogMailer{}, 10)
+	sent, err := SendOverdueNotices(context.Background(), db, mail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Errorf("queued %d emails, want 1", sent)
+	}
+
+	// Both overdue tasks are noticed, so a second run sends nothing
+	if sent, err := SendOverdueNotices(context.Background(), db, mail); err != nil || sent != 0 {
+		t.Fatalf("second run queued %d emails (err %v)", sent, err)
+	}
+	var noticed int64
+	if err := db.Model(&models.Task{}).Where("overdue_notified_at IS NOT NULL").Count(&noticed).Error; err != nil {
+		t.Fatal(err)
+	}
+	if noticed != 2 {
+		t.Errorf("%d tasks noticed, want 2", noticed)
+	}
+}
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/config"
+)
+
+func TestSMTPMailerGivesUpOnUnresponsiveServer(t *testing.T) {
+	// The server accepts connections but never sends its greeting
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := net.LookupPort("tcp", port)
+	m, err := NewSMTPMailer(config.MailConfig{
+		From:     "taskflow@example.com",
+		SMTPHost: host,
+		SMTPPort: portNumber,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = m.Send(ctx, Message{To: "pat@example.com", Subject: "Hi", Body: "Hello"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Send returned after %s, want it to stop at the deadline", elapsed)
+	}
+}
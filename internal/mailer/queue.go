@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// sendTimeout bounds how long a worker waits on a single message
+const sendTimeout = 30 * time.Second
+
+// Queue delivers messages in the background so requests never wait on the
+// mail server
+type Queue struct {
+	mailer   Mailer
+	messages chan Message
+}
+
+func NewQueue(mailer Mailer, size int) *Queue {
+	return &Queue{
+		mailer:   mailer,
+		messages: make(chan Message, size),
+	}
+}
+
+// Enqueue schedules a message without blocking. The message is dropped when
+// the queue is full.
+func (q *Queue) Enqueue(msg Message) bool {
+	select {
+	case q.messages <- msg:
+		return true
+	default:
+		log.Printf("⚠️  Mail queue full, dropping email to %s: %s", msg.To, msg.Subject)
+		return false
+	}
+}
+
+// Run delivers queued messages with the given number of workers until ctx is
+// cancelled. Messages still queued at that point are not sent.
+func (q *Queue) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-q.messages:
+					q.send(ctx, msg)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (q *Queue) send(ctx context.Context, msg Message) {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	if err := q.mailer.Send(ctx, msg); err != nil {
+		log.Printf("⚠️  Failed to send email to %s: %v", msg.To, err)
+	}
+}
@@ -0,0 +1,71 @@
+package mailer
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"taskflow-api/internal/models"
+)
+
+var taskAssignedTemplate = template.Must(template.New("task_assigned").Parse(
+	`Hi {{.Name}},
+
+You have been assigned to "{{.Task}}" in {{.Project}}.
+{{- if .DueDate}}
+It is due {{.DueDate}}.{{end}}
+`))
+
+var taskOverdueTemplate = template.Must(template.New("task_overdue").Parse(
+	`Hi {{.Name}},
+
+"{{.Task}}" in {{.Project}} was due {{.DueDate}} and is now overdue.
+Its priority has been raised to {{.Priority}}.
+`))
+
+type taskEmailData struct {
+	Name     string
+	Task     string
+	Project  string
+	DueDate  string
+	Priority models.TaskPriority
+}
+
+func newTaskEmailData(user *models.User, task *models.Task) taskEmailData {
+	data := taskEmailData{
+		Name:     user.FirstName,
+		Task:     task.Title,
+		Project:  task.Project.Name,
+		Priority: task.Priority,
+	}
+	if task.DueDate != nil {
+		data.DueDate = task.DueDate.UTC().Format(time.RFC1123)
+	}
+	return data
+}
+
+// TaskAssignedEmail builds the email sent to a task's new assignee. The task's
+// Project must be loaded.
+func TaskAssignedEmail(user *models.User, task *models.Task) (Message, error) {
+	return render(taskAssignedTemplate, user, "You have been assigned to "+task.Title,
+		newTaskEmailData(user, task))
+}
+
+// TaskOverdueEmail builds the email sent to an assignee when their task is
+// overdue and has been escalated. The task's Project must be loaded.
+func TaskOverdueEmail(user *models.User, task *models.Task) (Message, error) {
+	return render(taskOverdueTemplate, user, "Overdue: "+task.Title,
+		newTaskEmailData(user, task))
+}
+
+func render(tmpl *template.Template, user *models.User, subject string, data taskEmailData) (Message, error) {
+	var body strings.Builder
+	if err := tmpl.Execute(&body, data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      user.Email,
+		Subject: subject,
+		Body:    body.String(),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// ActiveUserMiddleware loads the authenticated user on every request and
+// rejects credentials belonging to users that were deleted or deactivated
+// after they were issued. The user check is skipped when AUTH_VERIFY_USER is
+// disabled; tokens restricted to one action are rejected either way.
+func ActiveUserMiddleware(cfg *config.Config, db *gorm.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// The reactivate route, the only one these tokens are for, does not
+		// run this middleware
+		if claims, err := GetJWTClaimsFromContext(c); err == nil && claims.Purpose != "" {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:     "Unauthorized",
+				Message:   "Token can only be used to reactivate the account",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrInvalidToken,
+			})
+		}
+
+		if !cfg.JWT.VerifyUser {
+			return c.Next()
+		}
+
+		userID, err := GetUserIDFromContext(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:     "Unauthorized",
+				Message:   "User not authenticated",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrUnauthenticated,
+			})
+		}
+
+		// Soft-deleted users are excluded by the default scope
+		var user models.User
+		if err := db.WithContext(c.UserContext()).Where("id = ? AND is_active = ?", userID, true).First(&user).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+					Error:     "Unauthorized",
+					Message:   "User account is inactive or no longer exists",
+					Code:      fiber.StatusUnauthorized,
+					ErrorCode: models.ErrUserInactive,
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to verify user",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+
+		c.Locals("user", &user)
+
+		return c.Next()
+	}
+}
+
+// GetUserFromContext returns the user loaded by ActiveUserMiddleware
+func GetUserFromContext(c *fiber.Ctx) (*models.User, error) {
+	user, ok := c.Locals("user").(*models.User)
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "User not loaded")
+	}
+	return user, nil
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// APIKeyHeader is the request header carrying an API key
+	APIKeyHeader = "X-API-Key"
+
+	apiKeyPrefix      = "tf_"
+	apiKeyPrefixChars = 8
+)
+
+// GenerateAPIKey returns a new random API key, its display prefix and the hash to store
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+
+	key = apiKeyPrefix + hex.EncodeToString(buf)
+	return key, key[:len(apiKeyPrefix)+apiKeyPrefixChars], HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 hash of an API key
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// AuthMiddleware authenticates requests with either an X-API-Key header or a
+// JWT bearer token and sets the same user context values for both
+func AuthMiddleware(cfg *config.Config, db *gorm.DB) fiber.Handler {
+	jwtMiddleware := JWTMiddleware(cfg)
+
+	return func(c *fiber.Ctx) error {
+		rawKey := c.Get(APIKeyHeader)
+		if rawKey == "" {
+			return jwtMiddleware(c)
+		}
+
+		// Look up the key together with its active owner
+		var apiKey models.APIKey
+		if err := db.WithContext(c.UserContext()).Joins("User").
+			Where("api_keys.key_hash = ? AND \"User\".is_active = ?", HashAPIKey(rawKey), true).
+			First(&apiKey).Error; err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:     "Unauthorized",
+				Message:   "Invalid API key",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrInvalidAPIKey,
+			})
+		}
+
+		now := time.Now()
+		if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(now) {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:     "Unauthorized",
+				Message:   "API key expired",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrAPIKeyExpired,
+			})
+		}
+
+		// Record usage without failing the request if the update fails
+		db.WithContext(c.UserContext()).Model(&apiKey).UpdateColumn("last_used_at", now)
+
+		// Set user info in context
+		c.Locals("user_id", apiKey.UserID)
+		c.Locals("user_email", apiKey.User.Email)
+		c.Locals("api_key_id", apiKey.ID)
+		c.Locals("api_key_scopes", apiKey.Scopes)
+
+		return c.Next()
+	}
+}
+
+// RequireScope rejects API key requests whose key lacks the given scope.
+// Requests authenticated with a JWT have full access and pass through.
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scopes, ok := c.Locals("api_key_scopes").(models.APIKeyScopes)
+		if !ok || scopes.Has(scope) {
+			return c.Next()
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:     "Forbidden",
+			Message:   "API key is missing the " + scope + " scope",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrInsufficientScope,
+		})
+	}
+}
+
+// RequireJWT rejects requests authenticated with an API key, for routes such
+// as key management that must only be reachable from an interactive session
+func RequireJWT() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Locals("api_key_id") == nil {
+			return c.Next()
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:     "Forbidden",
+			Message:   "This endpoint cannot be used with an API key",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrAPIKeyNotAllowed,
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -15,27 +16,43 @@ import (
 type JWTClaims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
+
+	// Set on tokens an admin issued to act as the user
+	ImpersonatedBy *uuid.UUID `json:"impersonated_by,omitempty"`
+
+	// Set on tokens that are only good for one action
+	Purpose string `json:"purpose,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
+// TokenPurposeReactivate marks a token that can only reactivate a
+// deactivated account
+const TokenPurposeReactivate = "reactivate"
+
+// ReactivationTokenDuration is how long a reactivation token stays valid
+const ReactivationTokenDuration = 15 * time.Minute
+
 func JWTMiddleware(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get token from Authorization header
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-				Error:   "Unauthorized",
-				Message: "Missing authorization header",
-				Code:    fiber.StatusUnauthorized,
+				Error:     "Unauthorized",
+				Message:   "Missing authorization header",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrMissingAuthHeader,
 			})
 		}
 
 		// Check if header starts with "Bearer "
 		if !strings.HasPrefix(authHeader, "Bearer ") {
 			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-				Error:   "Unauthorized",
-				Message: "Invalid authorization header format",
-				Code:    fiber.StatusUnauthorized,
+				Error:     "Unauthorized",
+				Message:   "Invalid authorization header format",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrInvalidAuthHeader,
 			})
 		}
 
@@ -43,68 +60,139 @@ func JWTMiddleware(cfg *config.Config) fiber.Handler {
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
 		// Parse and validate token
-		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(cfg.JWT.Secret), nil
-		})
-
+		claims, err := ParseJWT(tokenString, cfg)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-				Error:   "Unauthorized",
-				Message: "Invalid token",
-				Code:    fiber.StatusUnauthorized,
+				Error:     "Unauthorized",
+				Message:   err.Message,
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: err.Code,
 			})
 		}
 
-		// Validate token and extract claims
-		if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-			// Check if token is expired
-			if claims.ExpiresAt.Time.Before(time.Now()) {
-				return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-					Error:   "Unauthorized",
-					Message: "Token expired",
-					Code:    fiber.StatusUnauthorized,
-				})
-			}
-
-			// Set user info in context
-			c.Locals("user_id", claims.UserID)
-			c.Locals("user_email", claims.Email)
+		// Set user info in context
+		c.Locals("user_id", claims.UserID)
+		c.Locals("user_email", claims.Email)
+		c.Locals("jwt_claims", claims)
+		if claims.ImpersonatedBy != nil {
+			c.Locals("impersonated_by", *claims.ImpersonatedBy)
+		}
 
-			return c.Next()
+		return c.Next()
+	}
+}
+
+// TokenError explains why a JWT was rejected
+type TokenError struct {
+	Code    models.ErrorCode
+	Message string
+}
+
+func (e *TokenError) Error() string {
+	return e.Message
+}
+
+// ParseJWT checks a token's signature and expiry and returns its claims.
+// Tokens signed with any algorithm other than the configured one are rejected.
+func ParseJWT(tokenString string, cfg *config.Config) (*JWTClaims, *TokenError) {
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return verifyingKey(cfg), nil
+	}, jwt.WithValidMethods([]string{cfg.JWT.Algorithm}), jwt.WithExpirationRequired())
+	if err != nil {
+		// The parser checks the expiry itself, so an expired token is only
+		// told apart by its error
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, &TokenError{Code: models.ErrTokenExpired, Message: "Token expired"}
 		}
+		return nil, &TokenError{Code: models.ErrInvalidToken, Message: "Invalid token"}
+	}
 
-		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-			Error:   "Unauthorized",
-			Message: "Invalid token claims",
-			Code:    fiber.StatusUnauthorized,
-		})
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid {
+		return nil, &TokenError{Code: models.ErrInvalidToken, Message: "Invalid token claims"}
 	}
+
+	return claims, nil
 }
 
 // GenerateJWT creates a new JWT token for a user
 func GenerateJWT(user *models.User, cfg *config.Config) (string, error) {
-	// Parse JWT expiry duration
-	duration, err := time.ParseDuration(cfg.JWT.Expiry)
-	if err != nil {
-		duration = 24 * time.Hour // Default to 24 hours
+	token, _, err := GenerateJWTWithExpiry(user, cfg, TokenDuration(cfg.JWT.Expiry))
+	return token, err
+}
+
+// TokenDuration parses a JWT expiry duration string, falling back to 24 hours.
+// LoadConfig already rejects or warns about invalid values.
+func TokenDuration(expiry string) time.Duration {
+	duration, err := time.ParseDuration(expiry)
+	if err != nil || duration <= 0 {
+		return 24 * time.Hour // Default to 24 hours
 	}
+	return duration
+}
+
+// GenerateJWTWithExpiry creates a new JWT token for a user that expires after
+// the given duration and returns the token's expiry time
+func GenerateJWTWithExpiry(user *models.User, cfg *config.Config, duration time.Duration) (string, time.Time, error) {
+	return generateJWT(user, nil, "", cfg, duration)
+}
+
+// GenerateImpersonationJWT creates a token for a user on behalf of an admin.
+// The token carries the admin's ID so its actions can be attributed.
+func GenerateImpersonationJWT(user *models.User, adminID uuid.UUID, cfg *config.Config) (string, time.Time, error) {
+	return generateJWT(user, &adminID, "", cfg, TokenDuration(cfg.JWT.ImpersonationExpiry))
+}
+
+// GenerateReactivationJWT creates a short-lived token that a deactivated user
+// can only use to reactivate their account
+func GenerateReactivationJWT(user *models.User, cfg *config.Config) (string, time.Time, error) {
+	return generateJWT(user, nil, TokenPurposeReactivate, cfg, ReactivationTokenDuration)
+}
+
+func generateJWT(user *models.User, impersonatedBy *uuid.UUID, purpose string, cfg *config.Config, duration time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(duration)
 
 	// Create claims
 	claims := JWTClaims{
-		UserID: user.ID,
-		Email:  user.Email,
+		UserID:         user.ID,
+		Email:          user.Email,
+		ImpersonatedBy: impersonatedBy,
+		Purpose:        purpose,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Subject:   user.ID.String(),
 		},
 	}
 
 	// Create token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(cfg.JWT.Algorithm), claims)
+
+	// Sign token with the secret or private key
+	signed, err := token.SignedString(signingKey(cfg))
+	if err != nil {
+		return "", time.Time{}, err
+	}
 
-	// Sign token with secret
-	return token.SignedString([]byte(cfg.JWT.Secret))
+	return signed, expiresAt, nil
+}
+
+// signingKey returns the key tokens are signed with for the configured
+// algorithm
+func signingKey(cfg *config.Config) interface{} {
+	if cfg.JWT.Algorithm == config.JWTAlgorithmRS256 {
+		return cfg.JWT.PrivateKey
+	}
+	return []byte(cfg.JWT.Secret)
+}
+
+// verifyingKey returns the key token signatures are checked against
+func verifyingKey(cfg *config.Config) interface{} {
+	if cfg.JWT.Algorithm == config.JWTAlgorithmRS256 {
+		return cfg.JWT.PublicKey
+	}
+	return []byte(cfg.JWT.Secret)
 }
 
 // GetUserIDFromContext extracts user ID from fiber context
@@ -116,6 +204,39 @@ func GetUserIDFromContext(c *fiber.Ctx) (uuid.UUID, error) {
 	return userID, nil
 }
 
+// GetJWTClaimsFromContext extracts the validated JWT claims from fiber context
+func GetJWTClaimsFromContext(c *fiber.Ctx) (*JWTClaims, error) {
+	claims, ok := c.Locals("jwt_claims").(*JWTClaims)
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "Not authenticated with a JWT")
+	}
+	return claims, nil
+}
+
+// GetImpersonatorIDFromContext returns the admin acting as the user when the
+// request was made with an impersonation token
+func GetImpersonatorIDFromContext(c *fiber.Ctx) (uuid.UUID, bool) {
+	adminID, ok := c.Locals("impersonated_by").(uuid.UUID)
+	return adminID, ok
+}
+
+// RejectImpersonation blocks impersonation tokens from routes that could turn
+// a short-lived session into lasting access, such as creating API keys
+func RejectImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := GetImpersonatorIDFromContext(c); !ok {
+			return c.Next()
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+			Error:     "Forbidden",
+			Message:   "This endpoint cannot be used while impersonating a user",
+			Code:      fiber.StatusForbidden,
+			ErrorCode: models.ErrImpersonationNotAllowed,
+		})
+	}
+}
+
 // GetUserEmailFromContext extracts user email from fiber context
 func GetUserEmailFromContext(c *fiber.Ctx) (string, error) {
 	email, ok := c.Locals("user_email").(string)
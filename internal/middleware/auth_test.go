@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestJWTMiddlewareReportsExpiredTokens(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{
+		Algorithm: config.JWTAlgorithmHS256,
+		Secret:    "test-secret-that-is-long-enough-for-hs256",
+	}}
+	user := &models.User{ID: uuid.New(), Email: "pat@example.com"}
+
+	valid, _, err := GenerateJWTWithExpiry(user, cfg, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, _, err := GenerateJWTWithExpiry(user, cfg, -time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/", JWTMiddleware(cfg), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	tests := []struct {
+		name      string
+		token     string
+		status    int
+		errorCode models.ErrorCode
+	}{
+		{"valid", valid, fiber.StatusNoContent, ""},
+		{"expired", expired, fiber.StatusUnauthorized, models.ErrTokenExpired},
+		{"malformed", "not-a-token", fiber.StatusUnauthorized, models.ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tt.token)
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.errorCode == "" {
+				return
+			}
+
+			var body models.ErrorResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.ErrorCode != tt.errorCode {
+				t.Errorf("error_code = %q, want %q", body.ErrorCode, tt.errorCode)
+			}
+		})
+	}
+}
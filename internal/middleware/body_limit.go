@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects request bodies larger than limit with 413. The server-wide
+// limit is raised to fit uploads, so this keeps every other route at the
+// smaller limit; paths in exempt are skipped and enforce their own limit.
+func BodyLimit(limit int, exempt ...string) fiber.Handler {
+	skip := make(map[string]bool, len(exempt))
+	for _, p := range exempt {
+		skip[p] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		if skip[c.Path()] || len(c.Body()) <= limit {
+			return c.Next()
+		}
+
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(models.ErrorResponse{
+			Error:     "Request Entity Too Large",
+			Message:   "Request body is too large",
+			Code:      fiber.StatusRequestEntityTooLarge,
+			ErrorCode: models.ErrRequestTooLarge,
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireFeature answers 404 for routes behind a feature flag that is off in
+// this environment
+func RequireFeature(cfg *config.Config, name string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if cfg.FeatureEnabled(name) {
+			return c.Next()
+		}
+
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Message:   "This feature is not enabled",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrFeatureDisabled,
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// allowOrder is the order methods are listed in the Allow header
+var allowOrder = []string{
+	fiber.MethodGet,
+	fiber.MethodHead,
+	fiber.MethodPost,
+	fiber.MethodPut,
+	fiber.MethodPatch,
+	fiber.MethodDelete,
+	fiber.MethodOptions,
+}
+
+// Options answers OPTIONS requests with 204 and an Allow header listing the
+// methods registered for the path. It runs ahead of authentication so generic
+// HTTP tooling can discover routes; CORS preflights are answered earlier by
+// the CORS middleware. The route table is read on the first OPTIONS request,
+// so this can be registered before the routes.
+func Options(app *fiber.App) fiber.Handler {
+	var (
+		once   sync.Once
+		routes []fiber.Route
+	)
+
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodOptions {
+			return c.Next()
+		}
+		once.Do(func() {
+			routes = app.GetRoutes(true)
+		})
+
+		allowed := allowedMethods(routes, c.Path())
+		if len(allowed) == 0 {
+			return c.Next()
+		}
+
+		c.Set(fiber.HeaderAllow, strings.Join(allowed, ", "))
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// allowedMethods lists the methods of the routes matching path, plus OPTIONS.
+// When a static route matches, routes with parameters are ignored: the path
+// names that resource (e.g. /tasks/search), not an ID of the parameterized one
+// (/tasks/:id). It returns nil when no route matches.
+func allowedMethods(routes []fiber.Route, path string) []string {
+	var static, parameterized []string
+	for _, route := range routes {
+		if !routePathMatches(route.Path, path) {
+			continue
+		}
+		if isStaticRoute(route.Path) {
+			static = append(static, route.Method)
+		} else {
+			parameterized = append(parameterized, route.Method)
+		}
+	}
+	methods := static
+	if len(methods) == 0 {
+		methods = parameterized
+	}
+	if len(methods) == 0 {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(allowOrder))
+	for _, method := range allowOrder {
+		if method == fiber.MethodOptions || slices.Contains(methods, method) {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// isStaticRoute reports whether a route pattern has no :param or * segments
+func isStaticRoute(pattern string) bool {
+	return !strings.ContainsAny(pattern, ":*")
+}
+
+// routePathMatches reports whether a request path matches a route pattern
+// with :param segments and a trailing * wildcard. Like the router's defaults
+// it ignores case and a trailing slash.
+func routePathMatches(pattern, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range patternSegments {
+		if segment == "*" {
+			return true
+		}
+		if i >= len(pathSegments) {
+			return false
+		}
+		if strings.HasPrefix(segment, ":") {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if !strings.EqualFold(segment, pathSegments[i]) {
+			return false
+		}
+	}
+	return len(patternSegments) == len(pathSegments)
+}
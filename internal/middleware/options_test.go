@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRoutePathMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/api/v1/tasks", "/api/v1/tasks", true},
+		{"/api/v1/tasks", "/api/v1/tasks/", true},
+		{"/api/v1/tasks", "/API/V1/Tasks", true},
+		{"/api/v1/tasks", "/api/v1/task", false},
+		{"/api/v1/tasks", "/api/v1/tasks/1", false},
+		{"/api/v1/tasks/:id", "/api/v1/tasks/1", true},
+		{"/api/v1/tasks/:id", "/api/v1/tasks", false},
+		{"/api/v1/tasks/:id", "/api/v1/tasks//", false},
+		{"/api/v1/tasks/:id/status", "/api/v1/tasks/1/status", true},
+		{"/api/v1/tasks/:id/status", "/api/v1/tasks/1/assignee", false},
+		{"/uploads/*", "/uploads/avatars/me.png", true},
+		{"/uploads/*", "/downloads/me.png", false},
+	}
+	for _, tt := range tests {
+		if got := routePathMatches(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("routePathMatches(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAllowedMethods(t *testing.T) {
+	routes := []fiber.Route{
+		{Method: fiber.MethodGet, Path: "/tasks"},
+		{Method: fiber.MethodPost, Path: "/tasks"},
+		{Method: fiber.MethodGet, Path: "/tasks/search"},
+		{Method: fiber.MethodHead, Path: "/tasks/search"},
+		{Method: fiber.MethodGet, Path: "/tasks/:id"},
+		{Method: fiber.MethodPut, Path: "/tasks/:id"},
+		{Method: fiber.MethodDelete, Path: "/tasks/:id"},
+		{Method: fiber.MethodPatch, Path: "/tasks/:id/status"},
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/tasks", []string{fiber.MethodGet, fiber.MethodPost, fiber.MethodOptions}},
+		{"/tasks/123", []string{fiber.MethodGet, fiber.MethodPut, fiber.MethodDelete, fiber.MethodOptions}},
+		// The static route wins over /tasks/:id
+		{"/tasks/search", []string{fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions}},
+		{"/tasks/123/status", []string{fiber.MethodPatch, fiber.MethodOptions}},
+		{"/projects", nil},
+	}
+	for _, tt := range tests {
+		if got := allowedMethods(routes, tt.path); !slices.Equal(got, tt.want) {
+			t.Errorf("allowedMethods(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	app := fiber.New()
+	app.Use(Options(app))
+	noop := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/tasks/search", noop)
+	app.Get("/tasks/:id", noop)
+	app.Delete("/tasks/:id", noop)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodOptions, "/tasks/search", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusNoContent)
+	}
+	// Fiber registers HEAD alongside every GET
+	if allow := resp.Header.Get(fiber.HeaderAllow); allow != "GET, HEAD, OPTIONS" {
+		t.Errorf("Allow = %q, want %q", allow, "GET, HEAD, OPTIONS")
+	}
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodOptions, "/unknown", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode == fiber.StatusNoContent {
+		t.Error("OPTIONS on an unknown path answered 204")
+	}
+}
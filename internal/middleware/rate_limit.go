@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"strconv"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+// Rate limit headers sent on every response the limiter governs
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimit allows cfg.Max requests per client in each cfg.Window. Requests
+// are counted per user when it runs after authentication and per IP
+// otherwise. Responses carry the limit, the requests remaining and the
+// seconds until the window resets; rejected requests get 429 with the same
+// headers and Retry-After. One handler can guard both public and protected
+// routes since the two are keyed separately.
+func RateLimit(cfg config.RateLimitConfig) fiber.Handler {
+	if cfg.Max <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return limiter.New(limiter.Config{
+		Max:        cfg.Max,
+		Expiration: cfg.Window,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			if userID, err := GetUserIDFromContext(c); err == nil {
+				return "user:" + userID.String()
+			}
+			return "ip:" + c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			// The limiter only sets Retry-After on rejected requests
+			c.Set(HeaderRateLimitLimit, strconv.Itoa(cfg.Max))
+			c.Set(HeaderRateLimitRemaining, "0")
+			c.Set(HeaderRateLimitReset, c.GetRespHeader(fiber.HeaderRetryAfter))
+			return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse{
+				Error:     "Too Many Requests",
+				Message:   "Rate limit exceeded, retry after the window resets",
+				Code:      fiber.StatusTooManyRequests,
+				ErrorCode: models.ErrRateLimited,
+			})
+		},
+	})
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestBudget logs a warning for every request whose handlers take longer
+// than budget. The warning is structured and logged at WARN level, above the
+// plain access log, so slow routes are easy to alert on. A non-positive
+// budget disables it.
+func RequestBudget(budget time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if budget <= 0 {
+			return c.Next()
+		}
+
+		start := time.Now()
+		err := c.Next()
+		elapsed := time.Since(start)
+
+		if elapsed > budget {
+			attrs := []any{
+				slog.String("method", c.Method()),
+				// The matched route rather than the path keeps IDs out of the key
+				slog.String("route", c.Route().Path),
+				slog.Int("status", c.Response().StatusCode()),
+				slog.Duration("duration", elapsed),
+				slog.Duration("budget", budget),
+			}
+			if traceID := GetTraceIDFromContext(c); traceID != "" {
+				attrs = append(attrs, slog.String("trace_id", traceID))
+			}
+			slog.WarnContext(c.UserContext(), "request exceeded time budget", attrs...)
+		}
+		return err
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Timeout gives each request a context deadline. Handlers pass
+// c.UserContext() to GORM so queries still running at the deadline are
+// cancelled, and the request then fails with 504. The deadline replaces any
+// set by an earlier Timeout, so routes can override the global one in either
+// direction. A non-positive timeout disables the deadline.
+//
+// The deadline is the only cancellation a request gets: fasthttp does not
+// notice a client disconnecting while the handler runs, so Fiber's user
+// context is never cancelled and the work continues until it finishes or the
+// deadline passes.
+func Timeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.UserContext()), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		// Check the context the handler actually ran with; a route override
+		// has already been cancelled by the time an outer Timeout sees it
+		if errors.Is(c.UserContext().Err(), context.DeadlineExceeded) {
+			return c.Status(fiber.StatusGatewayTimeout).JSON(models.ErrorResponse{
+				Error:     "Gateway Timeout",
+				Message:   "The request took too long to complete",
+				Code:      fiber.StatusGatewayTimeout,
+				ErrorCode: models.ErrRequestTimeout,
+			})
+		}
+		return err
+	}
+}
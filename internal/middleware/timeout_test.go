@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestTimeout(t *testing.T) {
+	// waitForCancel blocks like a long query until its context ends
+	waitForCancel := func(c *fiber.Ctx) error {
+		select {
+		case <-c.UserContext().Done():
+			return c.UserContext().Err()
+		case <-time.After(5 * time.Second):
+			return c.SendString("finished")
+		}
+	}
+
+	app := fiber.New()
+	app.Use(Timeout(time.Hour))
+	app.Get("/fast", func(c *fiber.Ctx) error { return c.SendString("finished") })
+	app.Get("/slow", Timeout(20*time.Millisecond), waitForCancel)
+	app.Get("/unlimited", Timeout(0), func(c *fiber.Ctx) error {
+		if _, ok := c.UserContext().Deadline(); !ok {
+			return c.SendString("no deadline")
+		}
+		return c.SendStatus(fiber.StatusTeapot)
+	})
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/fast", fiber.StatusOK},
+		{"/slow", fiber.StatusGatewayTimeout},
+		// A disabled route timeout leaves the outer deadline in place
+		{"/unlimited", fiber.StatusTeapot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			start := time.Now()
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil), -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("took %s; the deadline did not cancel the handler", elapsed)
+			}
+		})
+	}
+}
+
+func TestTimeoutCancelsHandlerContext(t *testing.T) {
+	handlerCtx := make(chan context.Context, 1)
+	app := fiber.New()
+	app.Use(Timeout(20 * time.Millisecond))
+	app.Get("/", func(c *fiber.Ctx) error {
+		handlerCtx <- c.UserContext()
+		<-c.UserContext().Done()
+		return nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusGatewayTimeout {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusGatewayTimeout)
+	}
+	if err := (<-handlerCtx).Err(); err != context.DeadlineExceeded {
+		t.Errorf("handler context error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTimeoutCancelsQueries(t *testing.T) {
+	db := testutil.OpenDB(t)
+
+	app := fiber.New()
+	app.Use(Timeout(100 * time.Millisecond))
+	app.Get("/", func(c *fiber.Ctx) error {
+		if err := db.WithContext(c.UserContext()).Exec("SELECT pg_sleep(5)").Error; err != nil {
+			return err
+		}
+		return c.SendString("finished")
+	})
+
+	start := time.Now()
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusGatewayTimeout {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusGatewayTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %s; the deadline did not cancel the query", elapsed)
+	}
+}
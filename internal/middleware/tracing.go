@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"taskflow-api/internal/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware starts a span per request, continuing any trace context
+// sent by the caller, and exposes the trace ID in the X-Trace-ID header
+func TracingMiddleware() fiber.Handler {
+	tracer := otel.Tracer(tracing.TracerName)
+
+	return func(c *fiber.Ctx) error {
+		carrier := propagation.HeaderCarrier(http.Header(c.GetReqHeaders()))
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), carrier)
+
+		ctx, span := tracer.Start(ctx, c.Method()+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Method()),
+				attribute.String("url.path", c.Path()),
+				attribute.String("client.address", c.IP()),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+		if span.SpanContext().HasTraceID() {
+			c.Set("X-Trace-ID", span.SpanContext().TraceID().String())
+		}
+
+		err := c.Next()
+
+		// Name the span after the matched route to keep cardinality low
+		span.SetName(c.Method() + " " + c.Route().Path)
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			span.RecordError(err)
+			if e, ok := err.(*fiber.Error); ok {
+				status = e.Code
+			} else {
+				status = fiber.StatusInternalServerError
+			}
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return err
+	}
+}
+
+// ErrorTraceID adds the request's trace ID to JSON error bodies that don't
+// carry one, so handlers need not set ErrorResponse.TraceID themselves. It
+// must run inside the compression middleware, while bodies are still plain JSON.
+func ErrorTraceID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		// Returned errors are rendered by the app's error handler instead
+		if err != nil || c.Response().StatusCode() < fiber.StatusBadRequest || c.Response().IsBodyStream() {
+			return err
+		}
+		if !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return nil
+		}
+		traceID := GetTraceIDFromContext(c)
+		if traceID == "" {
+			return nil
+		}
+
+		if body, ok := withTraceID(c.Response().Body(), traceID); ok {
+			c.Response().SetBodyRaw(body)
+		}
+		return nil
+	}
+}
+
+// withTraceID appends a trace_id field to a JSON object, keeping the order of
+// the existing fields. It reports false when body is not a JSON object or
+// already has a trace_id.
+func withTraceID(body []byte, traceID string) ([]byte, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return nil, false
+	}
+	if _, ok := fields["trace_id"]; ok {
+		return nil, false
+	}
+
+	value, err := json.Marshal(traceID)
+	if err != nil {
+		return nil, false
+	}
+	end := bytes.LastIndexByte(body, '}')
+	out := make([]byte, 0, len(body)+len(value)+12)
+	out = append(out, body[:end]...)
+	if len(fields) > 0 {
+		out = append(out, ',')
+	}
+	out = append(out, `"trace_id":`...)
+	out = append(out, value...)
+	return append(out, body[end:]...), true
+}
+
+// GetTraceIDFromContext returns the current request's trace ID, if any
+func GetTraceIDFromContext(c *fiber.Ctx) string {
+	spanContext := trace.SpanContextFromContext(c.UserContext())
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
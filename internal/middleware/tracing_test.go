@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+// withTestSpan puts a span context with a known trace ID on every request
+func withTestSpan(c *fiber.Ctx) error {
+	traceID, _ := trace.TraceIDFromHex(testTraceID)
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{1},
+	})
+	c.SetUserContext(trace.ContextWithSpanContext(c.UserContext(), spanContext))
+	return c.Next()
+}
+
+func TestWithTraceID(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+		ok   bool
+	}{
+		{"appends after existing fields", `{"error":"Not Found","code":404}`, `{"error":"Not Found","code":404,"trace_id":"abc"}`, true},
+		{"empty object", `{}`, `{"trace_id":"abc"}`, true},
+		{"already set", `{"error":"x","trace_id":"other"}`, "", false},
+		{"array", `[1,2]`, "", false},
+		{"not json", `oops`, "", false},
+		{"null", `null`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := withTraceID([]byte(tt.body), "abc")
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && string(got) != tt.want {
+				t.Errorf("body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorTraceID(t *testing.T) {
+	app := fiber.New()
+	app.Use(withTestSpan, ErrorTraceID())
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse{
+			Error:     "Not Found",
+			Code:      fiber.StatusNotFound,
+			ErrorCode: models.ErrTaskNotFound,
+		})
+	})
+	app.Get("/ok", func(c *fiber.Ctx) error {
+		return c.JSON(models.SuccessResponse{Message: "ok"})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/missing", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var errResp models.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatal(err)
+	}
+	if errResp.TraceID != testTraceID {
+		t.Errorf("trace_id = %q, want %q", errResp.TraceID, testTraceID)
+	}
+	if errResp.ErrorCode != models.ErrTaskNotFound {
+		t.Errorf("error_code = %q, want %q", errResp.ErrorCode, models.ErrTaskNotFound)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/ok", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields["trace_id"]; ok {
+		t.Errorf("successful response got a trace_id: %s", body)
+	}
+}
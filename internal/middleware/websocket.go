@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/models"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebSocketAuth rejects requests that are not websocket upgrades and
+// authenticates the upgrade with a JWT. Browsers cannot set headers on
+// websocket requests, so the token may also be passed as ?token=.
+func WebSocketAuth(cfg *config.Config) fiber.Handler {
+	jwtAuth := JWTMiddleware(cfg)
+
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return c.Status(fiber.StatusUpgradeRequired).JSON(models.ErrorResponse{
+				Error:     "Upgrade Required",
+				Message:   "This endpoint only accepts websocket connections",
+				Code:      fiber.StatusUpgradeRequired,
+				ErrorCode: models.ErrUpgradeRequired,
+			})
+		}
+
+		if c.Get(fiber.HeaderAuthorization) == "" {
+			if token := c.Query("token"); token != "" {
+				c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+			}
+		}
+
+		return jwtAuth(c)
+	}
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// API key scopes; a write scope also grants read access to the same resource
+const (
+	ScopeProjectsRead  = "projects:read"
+	ScopeProjectsWrite = "projects:write"
+	ScopeTasksRead     = "tasks:read"
+	ScopeTasksWrite    = "tasks:write"
+	ScopeUsersRead     = "users:read"
+	ScopeUsersWrite    = "users:write"
+)
+
+// AllScopes grants full access and is used when a key is created without scopes
+var AllScopes = APIKeyScopes{ScopeProjectsWrite, ScopeTasksWrite, ScopeUsersWrite}
+
+// APIKeyScopes is stored as a space-separated list of scopes
+type APIKeyScopes []string
+
+func (s APIKeyScopes) Value() (driver.Value, error) {
+	return strings.Join(s, " "), nil
+}
+
+func (s *APIKeyScopes) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case nil:
+		raw = ""
+	default:
+		return fmt.Errorf("unsupported type for APIKeyScopes: %T", value)
+	}
+	*s = strings.Fields(raw)
+	return nil
+}
+
+// Has reports whether the scopes grant the given scope
+func (s APIKeyScopes) Has(scope string) bool {
+	resource, _, _ := strings.Cut(scope, ":")
+	for _, granted := range s {
+		if granted == scope || granted == resource+":write" {
+			return true
+		}
+	}
+	return false
+}
+
+type APIKey struct {
+	ID         uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name       string         `json:"name" gorm:"not null"`
+	Prefix     string         `json:"prefix" gorm:"not null"`
+	KeyHash    string         `json:"-" gorm:"uniqueIndex;not null"`
+	Scopes     APIKeyScopes   `json:"scopes" gorm:"type:text;not null"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+type APIKeyCreateRequest struct {
+	Name      string     `json:"name" form:"name" validate:"required,max=255"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" form:"expires_at"`
+	Scopes    []string   `json:"scopes,omitempty" form:"scopes" validate:"omitempty,dive,oneof=projects:read projects:write tasks:read tasks:write users:read users:write"`
+}
+
+type APIKeyResponse struct {
+	ID         uuid.UUID    `json:"id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	Scopes     APIKeyScopes `json:"scopes"`
+	LastUsedAt *time.Time   `json:"last_used_at"`
+	ExpiresAt  *time.Time   `json:"expires_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// APIKeyCreatedResponse includes the plaintext key, which is only ever returned once
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func (k *APIKey) ToResponse() APIKeyResponse {
+	return APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		LastUsedAt: k.LastUsedAt,
+		ExpiresAt:  k.ExpiresAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
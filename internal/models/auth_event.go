@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Auth event types
+const (
+	AuthEventLoginSucceeded     = "login_succeeded"
+	AuthEventLoginFailed        = "login_failed"
+	AuthEventRegistered         = "registered"
+	AuthEventAccountDeactivated = "account_deactivated"
+	AuthEventAccountReactivated = "account_reactivated"
+	AuthEventAccountDeleted     = "account_deleted"
+)
+
+// AuthEvent is an entry in the authentication audit log. UserID is empty for
+// failed logins with an unknown email; Email holds the address that was tried.
+type AuthEvent struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    *uuid.UUID `json:"user_id" gorm:"type:uuid;index"`
+	Email     string     `json:"email"`
+	Type      string     `json:"type" gorm:"not null"`
+	IPAddress string     `json:"ip_address"`
+	UserAgent string     `json:"user_agent"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AuthEventFilterQuery narrows the audit log; from and to are RFC 3339
+// timestamps bounding created_at
+type AuthEventFilterQuery struct {
+	UserID string `query:"user_id"`
+	Type   string `query:"type"`
+	From   string `query:"from"`
+	To     string `query:"to"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+// BoardColumn holds one page of a status column and the column's total
+type BoardColumn struct {
+	Tasks []TaskResponse `json:"tasks"`
+	Total int64          `json:"total"`
+}
+
+type ProjectBoardResponse struct {
+	ProjectID uuid.UUID                  `json:"project_id"`
+	Page      int                        `json:"page"`
+	Limit     int                        `json:"limit"`
+	Columns   map[TaskStatus]BoardColumn `json:"columns"`
+}
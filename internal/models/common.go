@@ -1,7 +1,21 @@
 package models
 
+import "encoding/json"
+
 // Common types and structures
 
+// NullableString is a PATCH field that tells an omitted value (Set is false)
+// apart from an explicit null (Set is true and Value is nil)
+type NullableString struct {
+	Set   bool
+	Value *string
+}
+
+func (n *NullableString) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	return json.Unmarshal(data, &n.Value)
+}
+
 type PaginationRequest struct {
 	Page  int `query:"page" validate:"min=1"`
 	Limit int `query:"limit" validate:"min=1,max=100"`
@@ -15,9 +29,18 @@ type PaginationResponse struct {
 }
 
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-	Code    int    `json:"code,omitempty"`
+	Error     string      `json:"error"`
+	Message   string      `json:"message,omitempty"`
+	Code      int         `json:"code,omitempty"`
+	ErrorCode ErrorCode   `json:"error_code,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+	TraceID   string      `json:"trace_id,omitempty"`
+}
+
+// BulkItemError describes why a single item of a bulk request was rejected
+type BulkItemError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
 }
 
 type SuccessResponse struct {
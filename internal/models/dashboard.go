@@ -0,0 +1,13 @@
+package models
+
+type DashboardTaskCounts struct {
+	Total   int64 `json:"total"`
+	Overdue int64 `json:"overdue"`
+	DueSoon int64 `json:"due_soon"`
+}
+
+type DashboardResponse struct {
+	ProjectsByStatus map[ProjectStatus]int64 `json:"projects_by_status"`
+	Tasks            DashboardTaskCounts     `json:"tasks"`
+	RecentTasks      []TaskResponse          `json:"recent_tasks"`
+}
@@ -0,0 +1,99 @@
+package models
+
+import "github.com/gofiber/fiber/v2"
+
+// ErrorCode is a stable, machine-readable identifier for an error that
+// clients can map to localized messages
+type ErrorCode string
+
+// Authentication errors
+const (
+	ErrUnauthenticated    ErrorCode = "ERR_UNAUTHENTICATED"
+	ErrMissingAuthHeader  ErrorCode = "ERR_MISSING_AUTH_HEADER"
+	ErrInvalidAuthHeader  ErrorCode = "ERR_INVALID_AUTH_HEADER"
+	ErrInvalidToken       ErrorCode = "ERR_INVALID_TOKEN"
+	ErrTokenExpired       ErrorCode = "ERR_TOKEN_EXPIRED"
+	ErrInvalidCredentials ErrorCode = "ERR_INVALID_CREDENTIALS"
+	ErrInvalidAPIKey      ErrorCode = "ERR_INVALID_API_KEY"
+	ErrAPIKeyExpired      ErrorCode = "ERR_API_KEY_EXPIRED"
+	ErrInsufficientScope  ErrorCode = "ERR_INSUFFICIENT_SCOPE"
+	ErrAPIKeyNotAllowed   ErrorCode = "ERR_API_KEY_NOT_ALLOWED"
+	ErrUserInactive       ErrorCode = "ERR_USER_INACTIVE"
+)
+
+// Request errors
+const (
+	ErrInvalidRequestBody ErrorCode = "ERR_INVALID_REQUEST_BODY"
+	ErrInvalidQuery       ErrorCode = "ERR_INVALID_QUERY"
+	ErrInvalidID          ErrorCode = "ERR_INVALID_ID"
+	ErrValidation         ErrorCode = "ERR_VALIDATION"
+	ErrRequestTooLarge    ErrorCode = "ERR_REQUEST_TOO_LARGE"
+	ErrUpgradeRequired    ErrorCode = "ERR_UPGRADE_REQUIRED"
+	ErrUnsupportedMedia   ErrorCode = "ERR_UNSUPPORTED_MEDIA_TYPE"
+	ErrRequestTimeout     ErrorCode = "ERR_REQUEST_TIMEOUT"
+	ErrRateLimited        ErrorCode = "ERR_RATE_LIMITED"
+	ErrDueDateInPast      ErrorCode = "ERR_DUE_DATE_IN_PAST"
+	ErrDueDateTooFar      ErrorCode = "ERR_DUE_DATE_TOO_FAR"
+)
+
+// Resource errors
+const (
+	ErrNotFound        ErrorCode = "ERR_NOT_FOUND"
+	ErrFeatureDisabled ErrorCode = "ERR_FEATURE_DISABLED"
+	ErrProjectNotFound ErrorCode = "ERR_PROJECT_NOT_FOUND"
+	ErrTaskNotFound    ErrorCode = "ERR_TASK_NOT_FOUND"
+	ErrUserNotFound    ErrorCode = "ERR_USER_NOT_FOUND"
+	ErrAPIKeyNotFound  ErrorCode = "ERR_API_KEY_NOT_FOUND"
+	ErrEmailTaken      ErrorCode = "ERR_EMAIL_TAKEN"
+)
+
+// Permission errors
+const (
+	ErrForbidden               ErrorCode = "ERR_FORBIDDEN"
+	ErrAdminRequired           ErrorCode = "ERR_ADMIN_REQUIRED"
+	ErrImpersonationNotAllowed ErrorCode = "ERR_IMPERSONATION_NOT_ALLOWED"
+)
+
+// Task and project errors
+const (
+	ErrAssigneeUnavailable ErrorCode = "ERR_ASSIGNEE_UNAVAILABLE"
+	ErrSameAssignee        ErrorCode = "ERR_SAME_ASSIGNEE"
+	ErrNewOwnerUnavailable ErrorCode = "ERR_NEW_OWNER_UNAVAILABLE"
+	ErrAlreadyOwner        ErrorCode = "ERR_ALREADY_OWNER"
+	ErrBulkEmpty           ErrorCode = "ERR_BULK_EMPTY"
+	ErrBulkTooLarge        ErrorCode = "ERR_BULK_TOO_LARGE"
+	ErrBulkInvalid         ErrorCode = "ERR_BULK_INVALID"
+	ErrProjectTaskLimit    ErrorCode = "ERR_PROJECT_TASK_LIMIT"
+	ErrProjectQuota        ErrorCode = "ERR_PROJECT_QUOTA"
+	ErrTaskNotDone         ErrorCode = "ERR_TASK_NOT_DONE"
+	ErrVersionConflict     ErrorCode = "ERR_VERSION_CONFLICT"
+	ErrTaskValueExists     ErrorCode = "ERR_TASK_VALUE_EXISTS"
+)
+
+// Generic errors
+const (
+	ErrMethodNotAllowed ErrorCode = "ERR_METHOD_NOT_ALLOWED"
+	ErrRequestFailed    ErrorCode = "ERR_REQUEST_FAILED"
+	ErrInternal         ErrorCode = "ERR_INTERNAL"
+)
+
+// ErrorCodeForStatus returns a generic error code for errors that are not
+// raised by a handler, such as unknown routes or oversized bodies
+func ErrorCodeForStatus(status int) ErrorCode {
+	switch status {
+	case fiber.StatusNotFound:
+		return ErrNotFound
+	case fiber.StatusMethodNotAllowed:
+		return ErrMethodNotAllowed
+	case fiber.StatusRequestEntityTooLarge:
+		return ErrRequestTooLarge
+	case fiber.StatusGatewayTimeout:
+		return ErrRequestTimeout
+	case fiber.StatusTooManyRequests:
+		return ErrRateLimited
+	case fiber.StatusInternalServerError:
+		return ErrInternal
+	default:
+		return ErrRequestFailed
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImpersonationLog records an admin issuing a token for another user. Rows are
+// never updated or deleted by the API.
+type ImpersonationLog struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	AdminID   uuid.UUID `json:"admin_id" gorm:"type:uuid;not null;index"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	Reason    *string   `json:"reason"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ImpersonationRequest struct {
+	Reason string `json:"reason,omitempty" form:"reason" validate:"omitempty,max=500"`
+}
+
+type ImpersonationTokenResponse struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	ExpiresIn int64        `json:"expires_in"`
+	User      UserResponse `json:"user"`
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationTypeTaskAssigned       NotificationType = "task_assigned"
+	NotificationTypeTaskOverdue        NotificationType = "task_overdue"
+	NotificationTypeProjectTransferred NotificationType = "project_transferred"
+)
+
+type Notification struct {
+	ID        uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID        `json:"user_id" gorm:"type:uuid;not null;index"`
+	Type      NotificationType `json:"type" gorm:"not null"`
+	TaskID    *uuid.UUID       `json:"task_id" gorm:"type:uuid;index"`
+	Message   string           `json:"message" gorm:"not null"`
+	Read      bool             `json:"read" gorm:"default:false"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+type NotificationResponse struct {
+	ID        uuid.UUID        `json:"id"`
+	Type      NotificationType `json:"type"`
+	TaskID    *uuid.UUID       `json:"task_id"`
+	Message   string           `json:"message"`
+	Read      bool             `json:"read"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type UnreadCountResponse struct {
+	Count int64 `json:"count"`
+}
+
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+func (n *Notification) ToResponse() NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID,
+		Type:      n.Type,
+		TaskID:    n.TaskID,
+		Message:   n.Message,
+		Read:      n.Read,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+// NewTaskAssignedNotification builds the notification sent to a task's new assignee
+func NewTaskAssignedNotification(task *Task, assigneeID uuid.UUID) Notification {
+	taskID := task.ID
+	return Notification{
+		UserID:  assigneeID,
+		Type:    NotificationTypeTaskAssigned,
+		TaskID:  &taskID,
+		Message: fmt.Sprintf("You have been assigned to task %q", task.Title),
+	}
+}
+
+// NewProjectTransferredNotification builds the notification sent to a project's new owner
+func NewProjectTransferredNotification(project *Project) Notification {
+	return Notification{
+		UserID:  project.OwnerID,
+		Type:    NotificationTypeProjectTransferred,
+		Message: fmt.Sprintf("You are now the owner of project %q", project.Name),
+	}
+}
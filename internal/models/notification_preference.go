@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationPreference records which events a user is notified about, in the
+// app and by email. Users without a row get DefaultNotificationPreference.
+type NotificationPreference struct {
+	UserID             uuid.UUID `json:"user_id" gorm:"type:uuid;primary_key"`
+	TaskAssigned       bool      `json:"task_assigned" gorm:"not null"`
+	TaskOverdue        bool      `json:"task_overdue" gorm:"not null"`
+	ProjectTransferred bool      `json:"project_transferred" gorm:"not null"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type NotificationPreferenceUpdateRequest struct {
+	TaskAssigned       *bool `json:"task_assigned,omitempty" form:"task_assigned"`
+	TaskOverdue        *bool `json:"task_overdue,omitempty" form:"task_overdue"`
+	ProjectTransferred *bool `json:"project_transferred,omitempty" form:"project_transferred"`
+}
+
+type NotificationPreferenceResponse struct {
+	TaskAssigned       bool `json:"task_assigned"`
+	TaskOverdue        bool `json:"task_overdue"`
+	ProjectTransferred bool `json:"project_transferred"`
+}
+
+// DefaultNotificationPreference opts users in to every notification
+func DefaultNotificationPreference(userID uuid.UUID) NotificationPreference {
+	return NotificationPreference{
+		UserID:             userID,
+		TaskAssigned:       true,
+		TaskOverdue:        true,
+		ProjectTransferred: true,
+	}
+}
+
+// Allows reports whether the user wants notifications of the given type
+func (p *NotificationPreference) Allows(notificationType NotificationType) bool {
+	switch notificationType {
+	case NotificationTypeTaskAssigned:
+		return p.TaskAssigned
+	case NotificationTypeTaskOverdue:
+		return p.TaskOverdue
+	case NotificationTypeProjectTransferred:
+		return p.ProjectTransferred
+	default:
+		return true
+	}
+}
+
+func (p *NotificationPreference) ToResponse() NotificationPreferenceResponse {
+	return NotificationPreferenceResponse{
+		TaskAssigned:       p.TaskAssigned,
+		TaskOverdue:        p.TaskOverdue,
+		ProjectTransferred: p.ProjectTransferred,
+	}
+}
@@ -16,45 +16,115 @@ const (
 )
 
 type Project struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Name        string         `json:"name" gorm:"not null"`
-	Description *string        `json:"description"`
-	Color       string         `json:"color" gorm:"default:'#6366f1'"`
-	OwnerID     uuid.UUID      `json:"owner_id" gorm:"type:uuid;not null;index"`
-	Status      ProjectStatus  `json:"status" gorm:"type:project_status;default:'active'"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                  uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name                string         `json:"name" gorm:"not null"`
+	Description         *string        `json:"description"`
+	Color               string         `json:"color" gorm:"default:'#6366f1'"`
+	OwnerID             uuid.UUID      `json:"owner_id" gorm:"type:uuid;not null;index"`
+	Status              ProjectStatus  `json:"status" gorm:"type:project_status;default:'active'"`
+	DefaultTaskPriority TaskPriority   `json:"default_task_priority" gorm:"type:task_priority;default:'medium'"`
+	AutoEscalateOverdue bool           `json:"auto_escalate_overdue" gorm:"default:false"`
+	AutoStartOnAssign   bool           `json:"auto_start_on_assign" gorm:"default:false"`
+	Version             int            `json:"version" gorm:"not null;default:1"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Owner User   `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
 	Tasks []Task `json:"tasks,omitempty" gorm:"foreignKey:ProjectID"`
 }
 
+// ProjectQuotaDetails explains why a user cannot create more projects
+type ProjectQuotaDetails struct {
+	Current int64 `json:"current"`
+	Limit   int   `json:"limit"`
+}
+
+// ProjectVersionConflictDetails tells a client which version to refetch after
+// a concurrent update
+type ProjectVersionConflictDetails struct {
+	CurrentVersion int `json:"current_version"`
+}
+
 type ProjectCreateRequest struct {
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description,omitempty"`
-	Color       string `json:"color,omitempty"`
+	Name                string        `json:"name" form:"name" validate:"required,project_name"`
+	Description         string        `json:"description,omitempty" form:"description" validate:"omitempty,project_description"`
+	Color               string        `json:"color,omitempty" form:"color" validate:"omitempty,project_color"`
+	DefaultTaskPriority *TaskPriority `json:"default_task_priority,omitempty" form:"default_task_priority" validate:"omitempty,task_priority"`
+	AutoEscalateOverdue bool          `json:"auto_escalate_overdue,omitempty" form:"auto_escalate_overdue"`
+	AutoStartOnAssign   bool          `json:"auto_start_on_assign,omitempty" form:"auto_start_on_assign"`
 }
 
 type ProjectUpdateRequest struct {
-	Name        string         `json:"name,omitempty"`
-	Description *string        `json:"description,omitempty"`
-	Color       string         `json:"color,omitempty"`
-	Status      *ProjectStatus `json:"status,omitempty"`
+	Name                string         `json:"name,omitempty" form:"name" validate:"omitempty,project_name"`
+	Description         *string        `json:"description,omitempty" form:"description" validate:"omitempty,project_description"`
+	Color               string         `json:"color,omitempty" form:"color" validate:"omitempty,project_color"`
+	Status              *ProjectStatus `json:"status,omitempty" form:"status"`
+	DefaultTaskPriority *TaskPriority  `json:"default_task_priority,omitempty" form:"default_task_priority" validate:"omitempty,task_priority"`
+	AutoEscalateOverdue *bool          `json:"auto_escalate_overdue,omitempty" form:"auto_escalate_overdue"`
+	AutoStartOnAssign   *bool          `json:"auto_start_on_assign,omitempty" form:"auto_start_on_assign"`
+
+	// Version the client last read; the update is rejected if it is stale
+	Version *int `json:"version,omitempty" form:"version"`
+}
+
+// ProjectPatchRequest changes only the fields present in the body. A null
+// description clears it; null is ignored for the other fields, which cannot
+// be empty.
+type ProjectPatchRequest struct {
+	Name                *string        `json:"name" validate:"omitempty,min=1,project_name"`
+	Description         NullableString `json:"description" validate:"omitempty,project_description"`
+	Color               *string        `json:"color" validate:"omitempty,project_color"`
+	Status              *ProjectStatus `json:"status" validate:"omitempty,oneof=active archived completed"`
+	DefaultTaskPriority *TaskPriority  `json:"default_task_priority" validate:"omitempty,task_priority"`
+	AutoEscalateOverdue *bool          `json:"auto_escalate_overdue"`
+	AutoStartOnAssign   *bool          `json:"auto_start_on_assign"`
+
+	// Version the client last read; the update is rejected if it is stale
+	Version *int `json:"version"`
+}
+
+type ProjectDuplicateRequest struct {
+	Name          string `json:"name,omitempty" form:"name" validate:"omitempty,project_name"`
+	CopyTasks     *bool  `json:"copy_tasks,omitempty" form:"copy_tasks"`
+	CopyAssignees bool   `json:"copy_assignees,omitempty" form:"copy_assignees"`
+}
+
+type ProjectTransferRequest struct {
+	NewOwnerID uuid.UUID `json:"new_owner_id" form:"new_owner_id"`
+}
+
+// ProjectRoleOwner is the only project role; projects have no other members
+const ProjectRoleOwner = "owner"
+
+// ProjectPermissionsResponse lists what the caller may do with a project so
+// clients can hide actions that would be refused
+type ProjectPermissionsResponse struct {
+	ProjectID        uuid.UUID `json:"project_id"`
+	Role             string    `json:"role"`
+	CanEdit          bool      `json:"can_edit"`
+	CanDelete        bool      `json:"can_delete"`
+	CanManageMembers bool      `json:"can_manage_members"`
 }
 
 type ProjectResponse struct {
-	ID          uuid.UUID     `json:"id"`
-	Name        string        `json:"name"`
-	Description *string       `json:"description"`
-	Color       string        `json:"color"`
-	OwnerID     uuid.UUID     `json:"owner_id"`
-	Status      ProjectStatus `json:"status"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
-	Owner       *UserResponse `json:"owner,omitempty"`
-	TasksCount  int           `json:"tasks_count,omitempty"`
+	ID                  uuid.UUID     `json:"id"`
+	Name                string        `json:"name"`
+	Description         *string       `json:"description"`
+	Color               string        `json:"color"`
+	OwnerID             uuid.UUID     `json:"owner_id"`
+	Status              ProjectStatus `json:"status"`
+	DefaultTaskPriority TaskPriority  `json:"default_task_priority"`
+	AutoEscalateOverdue bool          `json:"auto_escalate_overdue"`
+	AutoStartOnAssign   bool          `json:"auto_start_on_assign"`
+	Version             int           `json:"version"`
+	Favorited           bool          `json:"favorited"`
+	CreatedAt           time.Time     `json:"created_at"`
+	UpdatedAt           time.Time     `json:"updated_at"`
+	DeletedAt           *time.Time    `json:"deleted_at,omitempty"`
+	Owner               *UserResponse `json:"owner,omitempty"`
+	TasksCount          int           `json:"tasks_count,omitempty"`
 }
 
 type ProjectWithTasksResponse struct {
@@ -64,14 +134,22 @@ type ProjectWithTasksResponse struct {
 
 func (p *Project) ToResponse() ProjectResponse {
 	response := ProjectResponse{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Color:       p.Color,
-		OwnerID:     p.OwnerID,
-		Status:      p.Status,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:                  p.ID,
+		Name:                p.Name,
+		Description:         p.Description,
+		Color:               p.Color,
+		OwnerID:             p.OwnerID,
+		Status:              p.Status,
+		DefaultTaskPriority: p.DefaultTaskPriority,
+		AutoEscalateOverdue: p.AutoEscalateOverdue,
+		AutoStartOnAssign:   p.AutoStartOnAssign,
+		Version:             p.Version,
+		CreatedAt:           p.CreatedAt,
+		UpdatedAt:           p.UpdatedAt,
+	}
+
+	if p.DeletedAt.Valid {
+		response.DeletedAt = &p.DeletedAt.Time
 	}
 
 	if p.Owner.ID != uuid.Nil {
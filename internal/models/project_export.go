@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// ProjectExportFormat is the version of the export document; imports of any
+// other version are rejected
+const ProjectExportFormat = 1
+
+// ProjectExport is a self-contained copy of a project and its tasks, used to
+// back a project up or move it to another account or instance. It carries no
+// IDs or assignees, so the same document is accepted by the import endpoint,
+// which creates everything anew under the caller.
+type ProjectExport struct {
+	Format     int             `json:"format"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Project    ExportedProject `json:"project"`
+	Tasks      []ExportedTask  `json:"tasks" validate:"dive"`
+}
+
+type ExportedProject struct {
+	Name                string        `json:"name" validate:"required,project_name"`
+	Description         *string       `json:"description" validate:"omitempty,project_description"`
+	Color               string        `json:"color" validate:"omitempty,project_color"`
+	Status              ProjectStatus `json:"status" validate:"omitempty,oneof=active archived completed"`
+	DefaultTaskPriority TaskPriority  `json:"default_task_priority" validate:"omitempty,task_priority"`
+	AutoEscalateOverdue bool          `json:"auto_escalate_overdue"`
+	AutoStartOnAssign   bool          `json:"auto_start_on_assign"`
+}
+
+type ExportedTask struct {
+	Title        string       `json:"title" validate:"required,task_title"`
+	Description  *string      `json:"description" validate:"omitempty,task_description"`
+	Status       TaskStatus   `json:"status" validate:"omitempty,task_status"`
+	Priority     TaskPriority `json:"priority" validate:"omitempty,task_priority"`
+	DueDate      *time.Time   `json:"due_date"`
+	CompletedAt  *time.Time   `json:"completed_at"`
+	SnoozedUntil *time.Time   `json:"snoozed_until"`
+	EscalatedAt  *time.Time   `json:"escalated_at"`
+	ArchivedAt   *time.Time   `json:"archived_at"`
+}
+
+// ToExport converts a project and its loaded tasks to an export document
+func (p *Project) ToExport() ProjectExport {
+	export := ProjectExport{
+		Format:     ProjectExportFormat,
+		ExportedAt: time.Now(),
+		Project: ExportedProject{
+			Name:                p.Name,
+			Description:         p.Description,
+			Color:               p.Color,
+			Status:              p.Status,
+			DefaultTaskPriority: p.DefaultTaskPriority,
+			AutoEscalateOverdue: p.AutoEscalateOverdue,
+			AutoStartOnAssign:   p.AutoStartOnAssign,
+		},
+		Tasks: make([]ExportedTask, len(p.Tasks)),
+	}
+
+	for i, task := range p.Tasks {
+		export.Tasks[i] = ExportedTask{
+			Title:        task.Title,
+			Description:  task.Description,
+			Status:       task.Status,
+			Priority:     task.Priority,
+			DueDate:      task.DueDate,
+			CompletedAt:  task.CompletedAt,
+			SnoozedUntil: task.SnoozedUntil,
+			EscalatedAt:  task.EscalatedAt,
+			ArchivedAt:   task.ArchivedAt,
+		}
+	}
+
+	return export
+}
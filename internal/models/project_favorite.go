@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectFavorite records that a user pinned a project. Favorites are per
+// user, so they live in their own table rather than on the project.
+type ProjectFavorite struct {
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primary_key"`
+	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+}
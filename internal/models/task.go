@@ -25,74 +25,214 @@ const (
 )
 
 type Task struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Title       string         `json:"title" gorm:"not null"`
-	Description *string        `json:"description"`
-	ProjectID   uuid.UUID      `json:"project_id" gorm:"type:uuid;not null;index"`
-	AssigneeID  *uuid.UUID     `json:"assignee_id" gorm:"type:uuid;index"`
-	Status      TaskStatus     `json:"status" gorm:"type:task_status;default:'todo'"`
-	Priority    TaskPriority   `json:"priority" gorm:"type:task_priority;default:'medium'"`
-	DueDate     *time.Time     `json:"due_date"`
-	CompletedAt *time.Time     `json:"completed_at"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Title        string         `json:"title" gorm:"not null"`
+	Description  *string        `json:"description"`
+	ProjectID    uuid.UUID      `json:"project_id" gorm:"type:uuid;not null;index"`
+	AssigneeID   *uuid.UUID     `json:"assignee_id" gorm:"type:uuid;index"`
+	CreatedBy    uuid.UUID      `json:"created_by" gorm:"type:uuid;not null;index"`
+	Status       TaskStatus     `json:"status" gorm:"type:task_status;default:'todo'"`
+	Priority     TaskPriority   `json:"priority" gorm:"type:task_priority;default:'medium'"`
+	DueDate      *time.Time     `json:"due_date"`
+	CompletedAt  *time.Time     `json:"completed_at"`
+	SnoozedUntil *time.Time     `json:"snoozed_until"`
+	EscalatedAt  *time.Time     `json:"escalated_at"`
+	ArchivedAt   *time.Time     `json:"archived_at"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// When the assignee was emailed that the task is overdue; cleared along
+	// with escalated_at when the due date changes
+	OverdueNotifiedAt *time.Time `json:"-"`
 
 	// Relationships
 	Project  Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
 	Assignee *User   `json:"assignee,omitempty" gorm:"foreignKey:AssigneeID"`
+	Creator  *User   `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`
 }
 
 type TaskCreateRequest struct {
-	Title       string        `json:"title" validate:"required"`
-	Description string        `json:"description,omitempty"`
-	AssigneeID  *uuid.UUID    `json:"assignee_id,omitempty"`
-	Priority    *TaskPriority `json:"priority,omitempty"`
-	DueDate     *time.Time    `json:"due_date,omitempty"`
+	Title       string        `json:"title" form:"title" validate:"required,task_title"`
+	Description string        `json:"description,omitempty" form:"description" validate:"omitempty,task_description"`
+	AssigneeID  *uuid.UUID    `json:"assignee_id,omitempty" form:"assignee_id"`
+	Status      *TaskStatus   `json:"status,omitempty" form:"status" validate:"omitempty,task_status"`
+	Priority    *TaskPriority `json:"priority,omitempty" form:"priority" validate:"omitempty,task_priority"`
+	DueDate     *time.Time    `json:"due_date,omitempty" form:"due_date"`
+
+	// Skips the past due date check for backfilled tasks
+	AllowPastDueDate bool `json:"allow_past_due_date,omitempty" form:"allow_past_due_date"`
 }
 
 type TaskUpdateRequest struct {
-	Title       string        `json:"title,omitempty"`
-	Description *string       `json:"description,omitempty"`
-	AssigneeID  *uuid.UUID    `json:"assignee_id,omitempty"`
-	Status      *TaskStatus   `json:"status,omitempty"`
-	Priority    *TaskPriority `json:"priority,omitempty"`
-	DueDate     *time.Time    `json:"due_date,omitempty"`
+	Title       string        `json:"title,omitempty" form:"title" validate:"omitempty,task_title"`
+	Description *string       `json:"description,omitempty" form:"description" validate:"omitempty,task_description"`
+	AssigneeID  *uuid.UUID    `json:"assignee_id,omitempty" form:"assignee_id"`
+	Status      *TaskStatus   `json:"status,omitempty" form:"status" validate:"omitempty,task_status"`
+	Priority    *TaskPriority `json:"priority,omitempty" form:"priority" validate:"omitempty,task_priority"`
+	DueDate     *time.Time    `json:"due_date,omitempty" form:"due_date"`
 }
 
 type TaskStatusUpdateRequest struct {
-	Status TaskStatus `json:"status" validate:"required"`
+	Status TaskStatus `json:"status" form:"status" validate:"required,task_status"`
+}
+
+// TaskCloneRequest optionally overrides the copied task's title and assignee
+type TaskCloneRequest struct {
+	Title      string     `json:"title,omitempty" form:"title" validate:"omitempty,task_title"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty" form:"assignee_id"`
+}
+
+// TaskMergeRequest names the task a duplicate is merged into
+type TaskMergeRequest struct {
+	TargetID uuid.UUID `json:"target_id" form:"target_id"`
+}
+
+type TaskAssigneeUpdateRequest struct {
+	AssigneeID *uuid.UUID `json:"assignee_id" form:"assignee_id"`
+}
+
+// TaskBulkAssignRequest assigns several tasks to one user; a null assignee_id
+// unassigns them
+type TaskBulkAssignRequest struct {
+	TaskIDs    []uuid.UUID `json:"task_ids" form:"task_ids"`
+	AssigneeID *uuid.UUID  `json:"assignee_id" form:"assignee_id"`
+}
+
+// TaskBulkAssignResponse reports how many tasks changed assignee
+type TaskBulkAssignResponse struct {
+	Updated int `json:"updated"`
+}
+
+// TaskBulkUpdateRequest applies the same field changes to several tasks.
+// Fields left out are not changed.
+type TaskBulkUpdateRequest struct {
+	IDs        []uuid.UUID   `json:"ids" form:"ids"`
+	Status     *TaskStatus   `json:"status,omitempty" form:"status" validate:"omitempty,task_status"`
+	Priority   *TaskPriority `json:"priority,omitempty" form:"priority" validate:"omitempty,task_priority"`
+	AssigneeID *uuid.UUID    `json:"assignee_id,omitempty" form:"assignee_id"`
+	DueDate    *time.Time    `json:"due_date,omitempty" form:"due_date"`
+}
+
+// TaskBulkUpdateResult reports the outcome for one task of a bulk update.
+// Updated is false when the task already had the requested values.
+type TaskBulkUpdateResult struct {
+	ID      uuid.UUID    `json:"id"`
+	Updated bool         `json:"updated"`
+	Task    TaskResponse `json:"task"`
+}
+
+// TasksNotFoundDetails lists the requested tasks that do not exist in the project
+type TasksNotFoundDetails struct {
+	TaskIDs []uuid.UUID `json:"task_ids"`
+}
+
+// TaskFilterQuery holds the optional filters for listing a project's tasks.
+// AssigneeID may be a user ID or "none" for unassigned tasks.
+type TaskFilterQuery struct {
+	Status     string `query:"status" validate:"omitempty,task_status"`
+	Priority   string `query:"priority" validate:"omitempty,task_priority"`
+	AssigneeID string `query:"assignee_id"`
+	Unassigned bool   `query:"unassigned"`
+}
+
+// AssignedTaskFilterQuery holds the optional filters for listing a user's
+// assigned tasks
+type AssignedTaskFilterQuery struct {
+	Status   string `query:"status" validate:"omitempty,task_status"`
+	Priority string `query:"priority" validate:"omitempty,task_priority"`
+}
+
+// TaskListFilterQuery holds the optional filters for listing tasks across the
+// caller's projects. AssigneeID and CreatedBy may be a user ID or "me".
+type TaskListFilterQuery struct {
+	Status     string `query:"status" validate:"omitempty,task_status"`
+	Priority   string `query:"priority" validate:"omitempty,task_priority"`
+	AssigneeID string `query:"assignee_id"`
+	CreatedBy  string `query:"created_by"`
+}
+
+// AssigneeNone is the assignee_id filter value that matches unassigned tasks
+const AssigneeNone = "none"
+
+// UserMe is the user filter value that stands for the current user
+const UserMe = "me"
+
+// TaskLimitDetails explains why a project cannot take more tasks
+type TaskLimitDetails struct {
+	Current   int64 `json:"current"`
+	Limit     int   `json:"limit"`
+	Requested int   `json:"requested"`
+}
+
+type TaskSnoozeRequest struct {
+	SnoozedUntil *time.Time `json:"snoozed_until" form:"snoozed_until"`
+}
+
+// TaskArchiveRequest archives a task, or restores it when Archived is false.
+// Archived defaults to true when omitted.
+type TaskArchiveRequest struct {
+	Archived *bool `json:"archived,omitempty" form:"archived"`
+}
+
+// TaskTombstone tells a syncing client to drop a task deleted since its cursor
+type TaskTombstone struct {
+	ID        uuid.UUID `json:"id"`
+	Deleted   bool      `json:"deleted"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TaskChangesResponse lists a project's tasks changed since a sync cursor.
+// ServerTime is the since value to send on the next sync.
+type TaskChangesResponse struct {
+	Tasks      []TaskResponse  `json:"tasks"`
+	Deleted    []TaskTombstone `json:"deleted"`
+	ServerTime time.Time       `json:"server_time"`
 }
 
 type TaskResponse struct {
-	ID          uuid.UUID        `json:"id"`
-	Title       string           `json:"title"`
-	Description *string          `json:"description"`
-	ProjectID   uuid.UUID        `json:"project_id"`
-	AssigneeID  *uuid.UUID       `json:"assignee_id"`
-	Status      TaskStatus       `json:"status"`
-	Priority    TaskPriority     `json:"priority"`
-	DueDate     *time.Time       `json:"due_date"`
-	CompletedAt *time.Time       `json:"completed_at"`
-	CreatedAt   time.Time        `json:"created_at"`
-	UpdatedAt   time.Time        `json:"updated_at"`
-	Project     *ProjectResponse `json:"project,omitempty"`
-	Assignee    *UserResponse    `json:"assignee,omitempty"`
+	ID           uuid.UUID        `json:"id"`
+	Title        string           `json:"title"`
+	Description  *string          `json:"description"`
+	ProjectID    uuid.UUID        `json:"project_id"`
+	AssigneeID   *uuid.UUID       `json:"assignee_id"`
+	CreatedBy    uuid.UUID        `json:"created_by"`
+	Status       TaskStatus       `json:"status"`
+	Priority     TaskPriority     `json:"priority"`
+	DueDate      *time.Time       `json:"due_date"`
+	CompletedAt  *time.Time       `json:"completed_at"`
+	SnoozedUntil *time.Time       `json:"snoozed_until"`
+	EscalatedAt  *time.Time       `json:"escalated_at"`
+	ArchivedAt   *time.Time       `json:"archived_at"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	DeletedAt    *time.Time       `json:"deleted_at,omitempty"`
+	Project      *ProjectResponse `json:"project,omitempty"`
+	Assignee     *UserResponse    `json:"assignee,omitempty"`
+	Creator      *UserResponse    `json:"creator,omitempty"`
 }
 
 func (t *Task) ToResponse() TaskResponse {
 	response := TaskResponse{
-		ID:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		ProjectID:   t.ProjectID,
-		AssigneeID:  t.AssigneeID,
-		Status:      t.Status,
-		Priority:    t.Priority,
-		DueDate:     t.DueDate,
-		CompletedAt: t.CompletedAt,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+		ID:           t.ID,
+		Title:        t.Title,
+		Description:  t.Description,
+		ProjectID:    t.ProjectID,
+		AssigneeID:   t.AssigneeID,
+		CreatedBy:    t.CreatedBy,
+		Status:       t.Status,
+		Priority:     t.Priority,
+		DueDate:      t.DueDate,
+		CompletedAt:  t.CompletedAt,
+		SnoozedUntil: t.SnoozedUntil,
+		EscalatedAt:  t.EscalatedAt,
+		ArchivedAt:   t.ArchivedAt,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+
+	if t.DeletedAt.Valid {
+		response.DeletedAt = &t.DeletedAt.Time
 	}
 
 	if t.Project.ID != uuid.Nil {
@@ -105,16 +245,44 @@ func (t *Task) ToResponse() TaskResponse {
 		response.Assignee = &assigneeResponse
 	}
 
+	if t.Creator != nil && t.Creator.ID != uuid.Nil {
+		creatorResponse := t.Creator.ToResponse()
+		response.Creator = &creatorResponse
+	}
+
 	return response
 }
 
-// BeforeUpdate hook to set completed_at when status changes to done
-func (t *Task) BeforeUpdate(tx *gorm.DB) error {
+// EscalatedPriority returns the next priority level, capping at urgent
+func EscalatedPriority(p TaskPriority) TaskPriority {
+	switch p {
+	case TaskPriorityLow:
+		return TaskPriorityMedium
+	case TaskPriorityMedium:
+		return TaskPriorityHigh
+	default:
+		return TaskPriorityUrgent
+	}
+}
+
+// syncCompletedAt sets completed_at when the task is done and clears it otherwise
+func (t *Task) syncCompletedAt() {
 	if t.Status == TaskStatusDone && t.CompletedAt == nil {
 		now := time.Now()
 		t.CompletedAt = &now
 	} else if t.Status != TaskStatusDone {
 		t.CompletedAt = nil
 	}
+}
+
+// BeforeCreate hook to set completed_at for tasks created as done
+func (t *Task) BeforeCreate(tx *gorm.DB) error {
+	t.syncCompletedAt()
+	return nil
+}
+
+// BeforeUpdate hook to set completed_at when status changes to done
+func (t *Task) BeforeUpdate(tx *gorm.DB) error {
+	t.syncCompletedAt()
 	return nil
 }
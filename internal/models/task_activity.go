@@ -0,0 +1,73 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Task activity actions
+const (
+	TaskActivityEscalated = "escalated"
+	TaskActivityReopened  = "reopened"
+	TaskActivityMerged    = "merged"
+	// TaskActivityAutoStarted is a todo task moved to in_progress because it
+	// was assigned in a project with auto_start_on_assign
+	TaskActivityAutoStarted = "auto_started"
+)
+
+// TaskActivity is an entry in a task's activity log, written in the same
+// transaction as the change it records. ActorID is empty for changes made by
+// the system, such as overdue escalation.
+type TaskActivity struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TaskID    uuid.UUID  `json:"task_id" gorm:"type:uuid;not null;index"`
+	ActorID   *uuid.UUID `json:"actor_id" gorm:"type:uuid"`
+	Action    string     `json:"action" gorm:"not null"`
+	Payload   JSONObject `json:"payload" gorm:"type:jsonb;not null"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewTaskActivity builds an activity entry with payload encoded as JSON
+func NewTaskActivity(taskID uuid.UUID, actorID *uuid.UUID, action string, payload map[string]interface{}) (TaskActivity, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return TaskActivity{}, err
+	}
+	return TaskActivity{TaskID: taskID, ActorID: actorID, Action: action, Payload: data}, nil
+}
+
+// JSONObject is raw JSON stored in a jsonb column
+type JSONObject json.RawMessage
+
+// Value stores the JSON as text, which PostgreSQL casts to jsonb
+func (j JSONObject) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return "{}", nil
+	}
+	return string(j), nil
+}
+
+// Scan reads a jsonb column
+func (j *JSONObject) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*j = append((*j)[:0], v...)
+	case string:
+		*j = JSONObject(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSONObject", src)
+	}
+	return nil
+}
+
+// MarshalJSON writes the stored JSON as is
+func (j JSONObject) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("{}"), nil
+	}
+	return j, nil
+}
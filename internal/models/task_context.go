@@ -0,0 +1,23 @@
+package models
+
+import "github.com/google/uuid"
+
+// TaskContextResponse is the breadcrumb shown with a task: the names of its
+// project and assignee without the full objects
+type TaskContextResponse struct {
+	TaskID   uuid.UUID            `json:"task_id"`
+	Project  TaskContextProject   `json:"project"`
+	Assignee *TaskContextAssignee `json:"assignee"`
+}
+
+type TaskContextProject struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Color string    `json:"color"`
+}
+
+type TaskContextAssignee struct {
+	ID        uuid.UUID `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+}
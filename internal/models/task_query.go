@@ -0,0 +1,15 @@
+package models
+
+// TaskQueryFilter is one node of a structured task filter: either a group
+// combining child filters with And/Or, or a single Field/Op/Value condition
+type TaskQueryFilter struct {
+	And   []TaskQueryFilter `json:"and,omitempty"`
+	Or    []TaskQueryFilter `json:"or,omitempty"`
+	Field string            `json:"field,omitempty"`
+	Op    string            `json:"op,omitempty"`
+	Value interface{}       `json:"value,omitempty"`
+}
+
+type TaskQueryRequest struct {
+	Filter *TaskQueryFilter `json:"filter"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestSyncCompletedAt(t *testing.T) {
+	earlier := time.Now().Add(-24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		status      TaskStatus
+		completedAt *time.Time
+		wantSet     bool
+		wantKept    bool
+	}{
+		{"done sets completed_at", TaskStatusDone, nil, true, false},
+		{"done keeps an existing completed_at", TaskStatusDone, &earlier, true, true},
+		{"todo clears completed_at", TaskStatusTodo, &earlier, false, false},
+		{"in progress clears completed_at", TaskStatusInProgress, &earlier, false, false},
+		{"cancelled clears completed_at", TaskStatusCancelled, &earlier, false, false},
+		{"todo without completed_at stays empty", TaskStatusTodo, nil, false, false},
+	}
+
+	hooks := map[string]func(*Task) error{
+		"BeforeCreate": func(task *Task) error { return task.BeforeCreate(&gorm.DB{}) },
+		"BeforeUpdate": func(task *Task) error { return task.BeforeUpdate(&gorm.DB{}) },
+	}
+	for hookName, hook := range hooks {
+		for _, tt := range tests {
+			t.Run(hookName+"/"+tt.name, func(t *testing.T) {
+				task := Task{Status: tt.status, CompletedAt: tt.completedAt}
+				before := time.Now()
+				if err := hook(&task); err != nil {
+					t.Fatal(err)
+				}
+
+				switch {
+				case !tt.wantSet:
+					if task.CompletedAt != nil {
+						t.Errorf("completed_at = %v, want nil", task.CompletedAt)
+					}
+				case task.CompletedAt == nil:
+					t.Error("completed_at = nil, want set")
+				case tt.wantKept:
+					if !task.CompletedAt.Equal(earlier) {
+						t.Errorf("completed_at = %v, want it kept at %v", task.CompletedAt, earlier)
+					}
+				case task.CompletedAt.Before(before):
+					t.Errorf("completed_at = %v, want now", task.CompletedAt)
+				}
+			})
+		}
+	}
+}
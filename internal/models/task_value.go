@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Lookup tables listing the values of the task_status and task_priority enums
+const (
+	TaskStatusesTable   = "task_statuses"
+	TaskPrioritiesTable = "task_priorities"
+)
+
+// OpenStatusCondition holds for tasks whose status is not marked closed in
+// the task_statuses lookup table, so custom statuses count as open unless they
+// were created as closed
+const OpenStatusCondition = "tasks.status NOT IN (SELECT name::task_status FROM " + TaskStatusesTable + " WHERE closed)"
+
+// OpenStatusScope limits a tasks query to open tasks
+func OpenStatusScope(db *gorm.DB) *gorm.DB {
+	return db.Where(OpenStatusCondition)
+}
+
+// TaskValueOption is a row of a task value lookup table. Position orders
+// statuses through the workflow and priorities from lowest to highest. Closed
+// is only set for statuses.
+type TaskValueOption struct {
+	Name      string    `json:"name" gorm:"primary_key"`
+	Position  int       `json:"position" gorm:"not null"`
+	BuiltIn   bool      `json:"built_in" gorm:"not null"`
+	Closed    *bool     `json:"closed,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TaskValueCreateRequest struct {
+	Name string `json:"name" form:"name" validate:"required"`
+	// Closed marks a custom status as closing the task, like done
+	Closed bool `json:"closed" form:"closed"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskView records when a user last opened a task. There is one row per user
+// and task, so repeated views only move the timestamp.
+type TaskView struct {
+	UserID   uuid.UUID `json:"user_id" gorm:"type:uuid;primary_key"`
+	TaskID   uuid.UUID `json:"task_id" gorm:"type:uuid;primary_key"`
+	ViewedAt time.Time `json:"viewed_at" gorm:"not null"`
+}
+
+// RecentTaskResponse is a task together with when the caller last viewed it
+type RecentTaskResponse struct {
+	TaskResponse
+	ViewedAt time.Time `json:"viewed_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "github.com/google/uuid"
+
+type TrendPoint struct {
+	Date      string `json:"date"`
+	Created   int64  `json:"created"`
+	Completed int64  `json:"completed"`
+}
+
+type ProjectTrendResponse struct {
+	ProjectID uuid.UUID    `json:"project_id"`
+	Days      int          `json:"days"`
+	Points    []TrendPoint `json:"points"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,16 +9,20 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	Email        string         `json:"email" gorm:"uniqueIndex;not null"`
-	PasswordHash string         `json:"-" gorm:"not null"`
-	FirstName    string         `json:"first_name" gorm:"not null"`
-	LastName     string         `json:"last_name" gorm:"not null"`
-	AvatarURL    *string        `json:"avatar_url"`
-	IsActive     bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                 uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Email              string         `json:"email" gorm:"uniqueIndex;not null"`
+	PasswordHash       string         `json:"-" gorm:"not null"`
+	FirstName          string         `json:"first_name" gorm:"not null"`
+	LastName           string         `json:"last_name" gorm:"not null"`
+	AvatarURL          *string        `json:"avatar_url"`
+	Timezone           string         `json:"timezone" gorm:"not null;default:'UTC'"`
+	EmailNotifications bool           `json:"email_notifications" gorm:"not null;default:true"`
+	IsActive           bool           `json:"is_active" gorm:"default:true"`
+	IsAdmin            bool           `json:"is_admin" gorm:"default:false"`
+	ProjectQuota       *int           `json:"-"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Projects []Project `json:"projects,omitempty" gorm:"foreignKey:OwnerID"`
@@ -25,40 +30,84 @@ type User struct {
 }
 
 type UserCreateRequest struct {
-	Email     string `json:"email" validate:"required,email"`
-	Password  string `json:"password" validate:"required,min=6"`
-	FirstName string `json:"first_name" validate:"required"`
-	LastName  string `json:"last_name" validate:"required"`
-	AvatarURL string `json:"avatar_url,omitempty"`
+	Email     string `json:"email" form:"email" validate:"required,email"`
+	Password  string `json:"password" form:"password" validate:"required"`
+	FirstName string `json:"first_name" form:"first_name" validate:"required"`
+	LastName  string `json:"last_name" form:"last_name" validate:"required"`
+	AvatarURL string `json:"avatar_url,omitempty" form:"avatar_url"`
+	Timezone  string `json:"timezone,omitempty" form:"timezone" validate:"omitempty,timezone"`
+}
+
+// ReactivationDetails carries the token a deactivated user logs in with; it
+// is only accepted by POST /users/me/reactivate
+type ReactivationDetails struct {
+	ReactivationToken string    `json:"reactivation_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+type UserDeleteRequest struct {
+	Password string `json:"password" form:"password"`
+}
+
+type UserReassignTasksRequest struct {
+	NewAssigneeID uuid.UUID `json:"new_assignee_id" form:"new_assignee_id"`
 }
 
 type UserUpdateRequest struct {
-	FirstName string  `json:"first_name,omitempty"`
-	LastName  string  `json:"last_name,omitempty"`
-	AvatarURL *string `json:"avatar_url,omitempty"`
-	IsActive  *bool   `json:"is_active,omitempty"`
+	FirstName          string  `json:"first_name,omitempty" form:"first_name"`
+	LastName           string  `json:"last_name,omitempty" form:"last_name"`
+	AvatarURL          *string `json:"avatar_url,omitempty" form:"avatar_url"`
+	IsActive           *bool   `json:"is_active,omitempty" form:"is_active"`
+	Timezone           *string `json:"timezone,omitempty" form:"timezone" validate:"omitempty,timezone"`
+	EmailNotifications *bool   `json:"email_notifications,omitempty" form:"email_notifications"`
 }
 
 type UserResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	AvatarURL *string   `json:"avatar_url"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                 uuid.UUID `json:"id"`
+	Email              string    `json:"email"`
+	FirstName          string    `json:"first_name"`
+	LastName           string    `json:"last_name"`
+	AvatarURL          *string   `json:"avatar_url"`
+	Timezone           string    `json:"timezone"`
+	EmailNotifications bool      `json:"email_notifications"`
+	IsActive           bool      `json:"is_active"`
+	IsAdmin            bool      `json:"is_admin"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// UserAssignedTaskCounts counts the tasks assigned to a user
+type UserAssignedTaskCounts struct {
+	Total             int64 `json:"total"`
+	CompletedThisWeek int64 `json:"completed_this_week"`
+	Overdue           int64 `json:"overdue"`
+}
+
+// UserSummaryResponse holds the counts shown on a user's profile
+type UserSummaryResponse struct {
+	Projects      int64                  `json:"projects"`
+	Tasks         int64                  `json:"tasks"`
+	AssignedTasks UserAssignedTaskCounts `json:"assigned_tasks"`
 }
 
 func (u *User) ToResponse() UserResponse {
 	return UserResponse{
-		ID:        u.ID,
-		Email:     u.Email,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
-		AvatarURL: u.AvatarURL,
-		IsActive:  u.IsActive,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:                 u.ID,
+		Email:              u.Email,
+		FirstName:          u.FirstName,
+		LastName:           u.LastName,
+		AvatarURL:          u.AvatarURL,
+		Timezone:           u.Timezone,
+		EmailNotifications: u.EmailNotifications,
+		IsActive:           u.IsActive,
+		IsAdmin:            u.IsAdmin,
+		CreatedAt:          u.CreatedAt,
+		UpdatedAt:          u.UpdatedAt,
 	}
 }
+
+// NormalizeEmail lowercases and trims an email so lookups and the unique
+// index treat differently-cased addresses as the same account
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
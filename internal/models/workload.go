@@ -0,0 +1,26 @@
+package models
+
+import "github.com/google/uuid"
+
+// WorkloadFilterQuery narrows the workload to one status; without it open
+// tasks (neither done nor cancelled) are counted
+type WorkloadFilterQuery struct {
+	Status string `query:"status" validate:"omitempty,task_status"`
+}
+
+// AssigneeWorkload is how many matching tasks one user has in the project
+type AssigneeWorkload struct {
+	AssigneeID uuid.UUID `json:"assignee_id"`
+	Email      string    `json:"email"`
+	FirstName  string    `json:"first_name"`
+	LastName   string    `json:"last_name"`
+	TaskCount  int64     `json:"task_count"`
+}
+
+// ProjectWorkloadResponse lists assignees by task count, busiest first, with
+// the tasks nobody is assigned to counted separately
+type ProjectWorkloadResponse struct {
+	ProjectID  uuid.UUID          `json:"project_id"`
+	Assignees  []AssigneeWorkload `json:"assignees"`
+	Unassigned int64              `json:"unassigned"`
+}
@@ -0,0 +1,64 @@
+package routes_test
+
+import (
+	"testing"
+
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestImpersonationCannotChangeAccountOrOwnership(t *testing.T) {
+	app := testapp.New(t)
+	admin := testutil.CreateAdmin(t, app.DB, "admin@example.com")
+	user := testutil.CreateUser(t, app.DB, "user@example.com")
+	other := testutil.CreateUser(t, app.DB, "other@example.com")
+	project := testutil.CreateProject(t, app.DB, user.ID, "Owned")
+
+	token, _, err := middleware.GenerateImpersonationJWT(&user, admin.ID, app.Cfg)
+	if err != nil {
+		t.Fatalf("generate impersonation token: %v", err)
+	}
+
+	userPath := "/api/v1/users/" + user.ID.String()
+	requests := []struct {
+		method, path string
+		body         interface{}
+	}{
+		{fiber.MethodPost, "/api/v1/users/me/deactivate", nil},
+		{fiber.MethodPost, "/api/v1/users/me/reactivate", nil},
+		{fiber.MethodPut, userPath, fiber.Map{"first_name": "Changed"}},
+		{fiber.MethodDelete, userPath, nil},
+		{fiber.MethodPost, "/api/v1/projects/" + project.ID.String() + "/transfer", fiber.Map{"new_owner_id": other.ID}},
+	}
+	for _, r := range requests {
+		t.Run(r.method+" "+r.path, func(t *testing.T) {
+			resp := app.Do(t, r.method, r.path, token, r.body)
+			testapp.ExpectStatus(t, resp, fiber.StatusForbidden)
+
+			var body models.ErrorResponse
+			testapp.Decode(t, resp, &body)
+			if body.ErrorCode != models.ErrImpersonationNotAllowed {
+				t.Errorf("error_code = %q, want %q", body.ErrorCode, models.ErrImpersonationNotAllowed)
+			}
+		})
+	}
+
+	var stored models.User
+	if err := app.DB.First(&stored, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if !stored.IsActive || stored.FirstName != user.FirstName {
+		t.Errorf("user changed: active = %v, first_name = %q", stored.IsActive, stored.FirstName)
+	}
+	var owned models.Project
+	if err := app.DB.First(&owned, project.ID).Error; err != nil {
+		t.Fatalf("load project: %v", err)
+	}
+	if owned.OwnerID != user.ID {
+		t.Errorf("owner_id = %s, want %s", owned.OwnerID, user.ID)
+	}
+}
@@ -1,12 +1,21 @@
 package routes
 
 import (
+	"log"
+	"strings"
+	"time"
+
 	"taskflow-api/internal/config"
+	"taskflow-api/internal/events"
 	"taskflow-api/internal/handlers"
+	"taskflow-api/internal/mailer"
 	"taskflow-api/internal/middleware"
 	"taskflow-api/internal/models"
+	"taskflow-api/internal/storage"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -14,17 +23,40 @@ import (
 	"gorm.io/gorm"
 )
 
-func SetupRoutes(app *fiber.App, db *gorm.DB, cfg *config.Config) {
+// avatarUploadPath may exceed the general body limit up to AVATAR_MAX_SIZE
+const avatarUploadPath = "/api/v1/users/me/avatar"
+
+func SetupRoutes(app *fiber.App, db *gorm.DB, cfg *config.Config, mail *mailer.Queue, taskViews *handlers.TaskViewRecorder) {
 	// Middleware
 	app.Use(recover.New())
+	app.Use(middleware.BodyLimit(cfg.BodyLimit, avatarUploadPath))
+	app.Use(middleware.TracingMiddleware())
 	app.Use(logger.New(logger.Config{
 		Format: "[${ip}]:${port} ${status} - ${method} ${path}\n",
 	}))
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,HEAD,PUT,DELETE,PATCH",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+	app.Use(compress.New(compress.Config{
+		Level: compress.Level(cfg.CompressionLevel),
+		// Streaming responses must not be buffered for compression
+		Next: func(c *fiber.Ctx) bool {
+			return strings.HasPrefix(c.Path(), "/metrics") ||
+				strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream")
+		},
 	}))
+	// Error bodies get the trace ID before they are compressed
+	app.Use(middleware.ErrorTraceID())
+
+	corsConfig := cors.Config{
+		AllowOrigins: strings.Join(cfg.CORS.AllowOrigins, ","),
+		AllowMethods: strings.Join(cfg.CORS.AllowMethods, ","),
+		AllowHeaders: strings.Join(cfg.CORS.AllowHeaders, ","),
+	}
+	log.Printf("🌐 CORS policy: origins=%s methods=%s headers=%s",
+		corsConfig.AllowOrigins, corsConfig.AllowMethods, corsConfig.AllowHeaders)
+	app.Use(cors.New(corsConfig))
+	// GET routes also answer HEAD; OPTIONS lists the allowed methods
+	app.Use(middleware.Options(app))
+	app.Use(middleware.RequestBudget(cfg.RequestBudget))
+	app.Use(middleware.Timeout(cfg.RequestTimeout))
 
 	// Health check
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -37,100 +69,393 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, cfg *config.Config) {
 		})
 	})
 
+	// Uploaded files
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("❌ Invalid storage configuration: %v", err)
+	}
+	if cfg.Storage.Driver == config.StorageDriverLocal && strings.HasPrefix(cfg.Storage.PublicURL, "/") {
+		app.Static(cfg.Storage.PublicURL, cfg.Storage.LocalDir)
+	}
+
+	// Task change events shared by the handlers and live subscribers
+	broker := events.NewBroker()
+
+	// Task statuses and priorities, including custom ones
+	taskValues, err := handlers.LoadTaskValues(db)
+	if err != nil {
+		log.Fatalf("❌ Failed to load task statuses and priorities: %v", err)
+	}
+
+	// Task search, accent-insensitive when configured and available
+	search, err := handlers.NewTextSearch(db, cfg.Tasks.SearchUnaccent)
+	if err != nil {
+		log.Fatalf("❌ Failed to check for the unaccent extension: %v", err)
+	}
+
+	// Project ownership checks shared by the project and task handlers
+	ownership := handlers.NewOwnershipCache(cfg.Projects.OwnershipCacheTTL)
+
 	// Initialize handlers
-	userHandler := handlers.NewUserHandler(db)
-	projectHandler := handlers.NewProjectHandler(db)
-	taskHandler := handlers.NewTaskHandler(db)
+	userHandler := handlers.NewUserHandler(db, cfg, store, mail)
+	projectHandler := handlers.NewProjectHandler(db, cfg, taskValues, ownership, broker, mail)
+	taskHandler := handlers.NewTaskHandler(db, cfg, broker, mail, taskValues, ownership, taskViews, search)
+	taskValueHandler := handlers.NewTaskValueHandler(db, cfg, taskValues)
+	dashboardHandler := handlers.NewDashboardHandler(db)
+	notificationHandler := handlers.NewNotificationHandler(db)
+	apiKeyHandler := handlers.NewAPIKeyHandler(db)
+	eventsHandler := handlers.NewEventsHandler(db, broker)
+	webSocketHandler := handlers.NewWebSocketHandler(db, broker)
+	featureHandler := handlers.NewFeatureHandler(db, cfg)
+	impersonationHandler := handlers.NewImpersonationHandler(db, cfg, taskValues)
+	authEventHandler := handlers.NewAuthEventHandler(db, cfg)
 
 	// API routes
 	api := app.Group("/api/v1")
 
+	// Requests are limited per IP before login and per user after it
+	rateLimit := middleware.RateLimit(cfg.RateLimit)
+
 	// Auth routes (public)
 	auth := api.Group("/auth")
-	auth.Post("/register", userHandler.CreateUser)
-	auth.Post("/login", LoginHandler(db, cfg))
+	auth.Post("/register", rateLimit, userHandler.CreateUser)
+	auth.Post("/login", rateLimit, LoginHandler(db, cfg))
+	auth.Post("/validate", ValidateTokenHandler(db, cfg))
+
+	// Websocket for live task updates; authenticated during the upgrade
+	api.Get("/ws",
+		middleware.RequireFeature(cfg, config.FeatureWebSocket),
+		middleware.WebSocketAuth(cfg),
+		middleware.ActiveUserMiddleware(cfg, db),
+		websocket.New(webSocketHandler.HandleConnection),
+	)
+
+	// Reactivation skips the active user check so that a deactivated user can
+	// call it, with the restricted token login hands out for their account
+	api.Post("/users/me/reactivate", middleware.JWTMiddleware(cfg), middleware.RejectImpersonation(), rateLimit, userHandler.ReactivateUser)
 
 	// Protected routes
-	protected := api.Use(middleware.JWTMiddleware(cfg))
+	protected := api.Use(
+		middleware.AuthMiddleware(cfg, db),
+		middleware.ActiveUserMiddleware(cfg, db),
+		rateLimit,
+	)
+
+	// Current token and user (JWT only)
+	api.Get("/auth/me", middleware.RequireJWT(), MeHandler(db))
+
+	// API key scopes required per route (JWT sessions have full access)
+	usersRead := middleware.RequireScope(models.ScopeUsersRead)
+	usersWrite := middleware.RequireScope(models.ScopeUsersWrite)
+	projectsRead := middleware.RequireScope(models.ScopeProjectsRead)
+	projectsWrite := middleware.RequireScope(models.ScopeProjectsWrite)
+	tasksRead := middleware.RequireScope(models.ScopeTasksRead)
+	tasksWrite := middleware.RequireScope(models.ScopeTasksWrite)
 
-	// User routes
+	// Routes known to run slow queries get a longer deadline
+	slowRequest := middleware.Timeout(cfg.SlowRequestTimeout)
+
+	// User routes; impersonation tokens cannot change accounts or ownership
 	users := protected.Group("/users")
-	users.Get("/", userHandler.GetUsers)
-	users.Get("/:id", userHandler.GetUser)
-	users.Put("/:id", userHandler.UpdateUser)
-	users.Delete("/:id", userHandler.DeleteUser)
+	users.Get("/", usersRead, userHandler.GetUsers)
+	users.Post("/me/deactivate", middleware.RejectImpersonation(), usersWrite, userHandler.DeactivateUser)
+	users.Post("/me/avatar", usersWrite, userHandler.UploadAvatar)
+	users.Get("/me/summary", projectsRead, tasksRead, userHandler.GetMySummary)
+	users.Get("/:id", usersRead, userHandler.GetUser)
+	users.Put("/:id", middleware.RejectImpersonation(), usersWrite, userHandler.UpdateUser)
+	users.Delete("/:id", middleware.RejectImpersonation(), usersWrite, userHandler.DeleteUser)
+	users.Get("/:id/assigned-tasks", tasksRead, taskHandler.GetUserAssignedTasks)
+	users.Post("/:id/reassign-tasks", tasksWrite, userHandler.ReassignTasks)
 
 	// Project routes
 	projects := protected.Group("/projects")
-	projects.Post("/", projectHandler.CreateProject)
-	projects.Get("/", projectHandler.GetProjects)
-	projects.Get("/:id", projectHandler.GetProject)
-	projects.Put("/:id", projectHandler.UpdateProject)
-	projects.Delete("/:id", projectHandler.DeleteProject)
+	projects.Post("/", projectsWrite, projectHandler.CreateProject)
+	projects.Get("/", projectsRead, projectHandler.GetProjects)
+	projects.Post("/import", projectsWrite, tasksWrite, projectHandler.ImportProject)
+	projects.Get("/:id", projectsRead, projectHandler.GetProject)
+	projects.Get("/:id/trend", slowRequest, projectsRead, tasksRead, projectHandler.GetProjectTrend)
+	projects.Get("/:id/board", projectsRead, tasksRead, taskHandler.GetProjectBoard)
+	projects.Get("/:id/workload", projectsRead, tasksRead, usersRead, projectHandler.GetProjectWorkload)
+	projects.Get("/:id/permissions", projectsRead, projectHandler.GetProjectPermissions)
+	projects.Get("/:id/export", projectsRead, tasksRead, projectHandler.ExportProject)
+	projects.Get("/:id/assignees", projectsRead, tasksRead, usersRead, projectHandler.GetProjectAssignees)
+	projects.Get("/:id/events", middleware.RequireFeature(cfg, config.FeatureSSE), tasksRead, eventsHandler.StreamProjectEvents)
+	projects.Put("/:id", projectsWrite, projectHandler.UpdateProject)
+	projects.Patch("/:id", projectsWrite, projectHandler.PatchProject)
+	projects.Delete("/:id", projectsWrite, projectHandler.DeleteProject)
+	projects.Post("/:id/duplicate", projectsWrite, projectHandler.DuplicateProject)
+	projects.Post("/:id/transfer", middleware.RejectImpersonation(), projectsWrite, projectHandler.TransferProject)
+	projects.Post("/:id/favorite", projectsWrite, projectHandler.FavoriteProject)
+	projects.Delete("/:id/favorite", projectsWrite, projectHandler.UnfavoriteProject)
 
 	// Task routes
 	tasks := protected.Group("/tasks")
-	tasks.Get("/:id", taskHandler.GetTask)
-	tasks.Put("/:id", taskHandler.UpdateTask)
-	tasks.Delete("/:id", taskHandler.DeleteTask)
-	tasks.Patch("/:id/status", taskHandler.UpdateTaskStatus)
+	tasks.Get("/", tasksRead, taskHandler.ListTasks)
+	tasks.Get("/search", tasksRead, taskHandler.SearchTasks)
+	tasks.Get("/due-today", tasksRead, taskHandler.GetTasksDueToday)
+	tasks.Get("/recent", tasksRead, taskHandler.GetRecentTasks)
+	tasks.Get("/:id", tasksRead, taskHandler.GetTask)
+	tasks.Get("/:id/context", tasksRead, taskHandler.GetTaskContext)
+	tasks.Put("/:id", tasksWrite, taskHandler.UpdateTask)
+	tasks.Delete("/:id", tasksWrite, taskHandler.DeleteTask)
+	tasks.Patch("/:id/status", tasksWrite, taskHandler.UpdateTaskStatus)
+	tasks.Patch("/:id/reopen", tasksWrite, taskHandler.ReopenTask)
+	tasks.Patch("/:id/assignee", tasksWrite, taskHandler.UpdateTaskAssignee)
+	tasks.Patch("/:id/snooze", tasksWrite, taskHandler.SnoozeTask)
+	tasks.Patch("/:id/archive", tasksWrite, taskHandler.ArchiveTask)
+	tasks.Post("/:id/clone", tasksWrite, taskHandler.CloneTask)
+	tasks.Post("/:id/merge", tasksWrite, taskHandler.MergeTask)
+
+	// Task status and priority routes; adding values is admin only
+	protected.Get("/task-statuses", tasksRead, taskValueHandler.GetTaskStatuses)
+	protected.Post("/task-statuses", tasksWrite, taskValueHandler.CreateTaskStatus)
+	protected.Get("/task-priorities", tasksRead, taskValueHandler.GetTaskPriorities)
+	protected.Post("/task-priorities", tasksWrite, taskValueHandler.CreateTaskPriority)
+
+	// API key routes (not manageable with an API key)
+	apiKeys := protected.Group("/api-keys", middleware.RequireJWT())
+	apiKeys.Post("/", middleware.RejectImpersonation(), apiKeyHandler.CreateAPIKey)
+	apiKeys.Get("/", apiKeyHandler.GetAPIKeys)
+	apiKeys.Delete("/:id", apiKeyHandler.RevokeAPIKey)
+
+	// Admin routes (not reachable with an API key)
+	protected.Get("/admin/features", middleware.RequireJWT(), featureHandler.GetFeatures)
+	protected.Post("/admin/users/:id/token", middleware.RequireJWT(), impersonationHandler.IssueUserToken)
+	protected.Get("/admin/auth-events", middleware.RequireJWT(), authEventHandler.GetAuthEvents)
+
+	// Dashboard routes
+	protected.Get("/dashboard", slowRequest, projectsRead, tasksRead, dashboardHandler.GetDashboard)
+
+	// Notification routes
+	notifications := protected.Group("/notifications")
+	notifications.Get("/unread-count", usersRead, notificationHandler.GetUnreadCount)
+	notifications.Post("/read-all", usersWrite, notificationHandler.MarkAllRead)
+	notifications.Get("/preferences", usersRead, notificationHandler.GetPreferences)
+	notifications.Put("/preferences", usersWrite, notificationHandler.UpdatePreferences)
 
 	// Project-specific task routes
 	projectTasks := protected.Group("/projects/:project_id/tasks")
-	projectTasks.Post("/", taskHandler.CreateTask)
-	projectTasks.Get("/", taskHandler.GetProjectTasks)
+	projectTasks.Post("/", tasksWrite, taskHandler.CreateTask)
+	projectTasks.Get("/", tasksRead, taskHandler.GetProjectTasks)
+	projectTasks.Get("/changes", tasksRead, taskHandler.GetProjectTaskChanges)
+	projectTasks.Post("/bulk", tasksWrite, taskHandler.BulkCreateTasks)
+	projectTasks.Patch("/bulk-assign", tasksWrite, taskHandler.BulkAssignTasks)
+	projectTasks.Patch("/bulk-update", tasksWrite, taskHandler.BulkUpdateTasks)
+	projectTasks.Post("/query", tasksRead, taskHandler.QueryProjectTasks)
 }
 
 // LoginHandler handles user authentication
 func LoginHandler(db *gorm.DB, cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var req struct {
-			Email    string `json:"email" validate:"required,email"`
-			Password string `json:"password" validate:"required"`
+			Email      string `json:"email" form:"email" validate:"required,email"`
+			Password   string `json:"password" form:"password" validate:"required"`
+			RememberMe bool   `json:"remember_me" form:"remember_me"`
 		}
 
 		if err := c.BodyParser(&req); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
-				Error:   "Bad Request",
-				Message: "Invalid request body",
-				Code:    fiber.StatusBadRequest,
+				Error:     "Bad Request",
+				Message:   "Invalid request body",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidRequestBody,
 			})
 		}
 
 		// Find user by email
+		email := models.NormalizeEmail(req.Email)
 		var user models.User
-		if err := db.Where("email = ? AND is_active = ?", req.Email, true).First(&user).Error; err != nil {
+		if err := db.WithContext(c.UserContext()).Where("email = ?", email).First(&user).Error; err != nil {
+			handlers.RecordAuthEvent(c, db, cfg, models.AuthEventLoginFailed, nil, email)
 			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-				Error:   "Unauthorized",
-				Message: "Invalid credentials",
-				Code:    fiber.StatusUnauthorized,
+				Error:     "Unauthorized",
+				Message:   "Invalid credentials",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrInvalidCredentials,
 			})
 		}
 
 		// Verify password
 		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+			handlers.RecordAuthEvent(c, db, cfg, models.AuthEventLoginFailed, &user.ID, email)
 			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
-				Error:   "Unauthorized",
-				Message: "Invalid credentials",
-				Code:    fiber.StatusUnauthorized,
+				Error:     "Unauthorized",
+				Message:   "Invalid credentials",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrInvalidCredentials,
+			})
+		}
+
+		// A deactivated account gets no session, only a token to reactivate it
+		if !user.IsActive {
+			token, expiresAt, err := middleware.GenerateReactivationJWT(&user, cfg)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+					Error:     "Internal Server Error",
+					Message:   "Failed to generate token",
+					Code:      fiber.StatusInternalServerError,
+					ErrorCode: models.ErrInternal,
+				})
+			}
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse{
+				Error:     "Forbidden",
+				Message:   "User account is deactivated; reactivate it with the reactivation token",
+				Code:      fiber.StatusForbidden,
+				ErrorCode: models.ErrUserInactive,
+				Details:   models.ReactivationDetails{ReactivationToken: token, ExpiresAt: expiresAt},
 			})
 		}
 
+		// Use the longer-lived expiry when the client asks to be remembered
+		duration := middleware.TokenDuration(cfg.JWT.Expiry)
+		if req.RememberMe {
+			duration = middleware.TokenDuration(cfg.JWT.RememberMeExpiry)
+		}
+
 		// Generate JWT token
-		token, err := middleware.GenerateJWT(&user, cfg)
+		token, expiresAt, err := middleware.GenerateJWTWithExpiry(&user, cfg, duration)
 		if err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
-				Error:   "Internal Server Error",
-				Message: "Failed to generate token",
-				Code:    fiber.StatusInternalServerError,
+				Error:     "Internal Server Error",
+				Message:   "Failed to generate token",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
 			})
 		}
 
+		handlers.RecordAuthEvent(c, db, cfg, models.AuthEventLoginSucceeded, &user.ID, email)
+
 		return c.JSON(models.SuccessResponse{
 			Message: "Login successful",
 			Data: fiber.Map{
-				"token": token,
+				"token":      token,
+				"expires_at": expiresAt,
+				"expires_in": int64(duration.Seconds()),
+				"user":       user.ToResponse(),
+			},
+		})
+	}
+}
+
+// ValidateTokenHandler reports whether a JWT passed in the body would be
+// accepted, running the same checks as the auth middleware without acting on
+// the token. Only the subject and expiry of a valid token are returned.
+func ValidateTokenHandler(db *gorm.DB, cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req struct {
+			Token string `json:"token" form:"token"`
+		}
+
+		if err := c.BodyParser(&req); err != nil || req.Token == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse{
+				Error:     "Bad Request",
+				Message:   "Request body must include a token",
+				Code:      fiber.StatusBadRequest,
+				ErrorCode: models.ErrInvalidRequestBody,
+			})
+		}
+
+		invalid := func(code models.ErrorCode, reason string) error {
+			return c.JSON(models.SuccessResponse{
+				Message: "Token is invalid",
+				Data: fiber.Map{
+					"valid":      false,
+					"error_code": code,
+					"reason":     reason,
+				},
+			})
+		}
+
+		claims, tokenErr := middleware.ParseJWT(req.Token, cfg)
+		if tokenErr != nil {
+			return invalid(tokenErr.Code, tokenErr.Message)
+		}
+
+		// Mirror ActiveUserMiddleware: tokens restricted to one action are not
+		// sessions
+		if claims.Purpose != "" {
+			return invalid(models.ErrInvalidToken, "Token can only be used to reactivate the account")
+		}
+
+		// Mirror ActiveUserMiddleware
+		if cfg.JWT.VerifyUser {
+			var count int64
+			if err := db.WithContext(c.UserContext()).Model(&models.User{}).
+				Where("id = ? AND is_active = ?", claims.UserID, true).
+				Count(&count).Error; err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+					Error:     "Internal Server Error",
+					Message:   "Failed to verify user",
+					Code:      fiber.StatusInternalServerError,
+					ErrorCode: models.ErrInternal,
+				})
+			}
+			if count == 0 {
+				return invalid(models.ErrUserInactive, "User account is inactive or no longer exists")
+			}
+		}
+
+		return c.JSON(models.SuccessResponse{
+			Message: "Token is valid",
+			Data: fiber.Map{
+				"valid":      true,
+				"subject":    claims.Subject,
+				"expires_at": claims.ExpiresAt.Time,
+				"expires_in": int64(time.Until(claims.ExpiresAt.Time).Seconds()),
+			},
+		})
+	}
+}
+
+// MeHandler returns the authenticated user together with details of the JWT
+// used for the request
+func MeHandler(db *gorm.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, err := middleware.GetJWTClaimsFromContext(c)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+				Error:     "Unauthorized",
+				Message:   "User not authenticated",
+				Code:      fiber.StatusUnauthorized,
+				ErrorCode: models.ErrUnauthenticated,
+			})
+		}
+
+		var user models.User
+		if err := db.WithContext(c.UserContext()).Where("id = ?", claims.UserID).First(&user).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse{
+					Error:     "Unauthorized",
+					Message:   "User no longer exists",
+					Code:      fiber.StatusUnauthorized,
+					ErrorCode: models.ErrUserInactive,
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse{
+				Error:     "Internal Server Error",
+				Message:   "Failed to fetch user",
+				Code:      fiber.StatusInternalServerError,
+				ErrorCode: models.ErrInternal,
+			})
+		}
+
+		token := fiber.Map{
+			"subject":    claims.Subject,
+			"expires_at": claims.ExpiresAt.Time,
+			"expires_in": int64(time.Until(claims.ExpiresAt.Time).Seconds()),
+		}
+		if claims.IssuedAt != nil {
+			token["issued_at"] = claims.IssuedAt.Time
+		}
+		if claims.ImpersonatedBy != nil {
+			token["impersonated_by"] = *claims.ImpersonatedBy
+		}
+
+		return c.JSON(models.SuccessResponse{
+			Message: "Token is valid",
+			Data: fiber.Map{
 				"user":  user.ToResponse(),
+				"token": token,
 			},
 		})
 	}
@@ -0,0 +1,73 @@
+package routes_test
+
+import (
+	"testing"
+
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type loginData struct {
+	Token string `json:"token"`
+}
+
+func TestDeactivateReactivateLogin(t *testing.T) {
+	app := testapp.New(t)
+	credentials := fiber.Map{"email": "pat@example.com", "password": "secret123"}
+
+	resp := app.Do(t, fiber.MethodPost, "/api/v1/auth/register", "", models.UserCreateRequest{
+		Email:     "pat@example.com",
+		Password:  "secret123",
+		FirstName: "Pat",
+		LastName:  "Doe",
+	})
+	testapp.ExpectStatus(t, resp, fiber.StatusCreated)
+
+	resp = app.Do(t, fiber.MethodPost, "/api/v1/auth/login", "", credentials)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+	var login testapp.Response[loginData]
+	testapp.Decode(t, resp, &login)
+
+	resp = app.Do(t, fiber.MethodPost, "/api/v1/users/me/deactivate", login.Data.Token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+
+	// The old session stops working
+	resp = app.Do(t, fiber.MethodGet, "/api/v1/users/me/summary", login.Data.Token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusUnauthorized)
+
+	// Logging in only hands out a reactivation token
+	resp = app.Do(t, fiber.MethodPost, "/api/v1/auth/login", "", credentials)
+	testapp.ExpectStatus(t, resp, fiber.StatusForbidden)
+	var denied struct {
+		ErrorCode models.ErrorCode           `json:"error_code"`
+		Details   models.ReactivationDetails `json:"details"`
+	}
+	testapp.Decode(t, resp, &denied)
+	if denied.ErrorCode != models.ErrUserInactive {
+		t.Errorf("error_code = %q, want %q", denied.ErrorCode, models.ErrUserInactive)
+	}
+	reactivationToken := denied.Details.ReactivationToken
+	if reactivationToken == "" {
+		t.Fatal("login returned no reactivation token")
+	}
+
+	// A wrong password reveals nothing about the account
+	resp = app.Do(t, fiber.MethodPost, "/api/v1/auth/login", "", fiber.Map{"email": "pat@example.com", "password": "wrong"})
+	testapp.ExpectStatus(t, resp, fiber.StatusUnauthorized)
+
+	// The reactivation token is good for nothing else
+	resp = app.Do(t, fiber.MethodGet, "/api/v1/users/me/summary", reactivationToken, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusUnauthorized)
+
+	resp = app.Do(t, fiber.MethodPost, "/api/v1/users/me/reactivate", reactivationToken, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+
+	resp = app.Do(t, fiber.MethodPost, "/api/v1/auth/login", "", credentials)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+	testapp.Decode(t, resp, &login)
+
+	resp = app.Do(t, fiber.MethodGet, "/api/v1/users/me/summary", login.Data.Token, nil)
+	testapp.ExpectStatus(t, resp, fiber.StatusOK)
+}
@@ -0,0 +1,50 @@
+package routes_test
+
+import (
+	"testing"
+
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/testutil"
+	"taskflow-api/internal/testutil/testapp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type validateData struct {
+	Valid     bool             `json:"valid"`
+	ErrorCode models.ErrorCode `json:"error_code"`
+}
+
+func TestValidateRejectsReactivationToken(t *testing.T) {
+	app := testapp.New(t)
+	user := testutil.CreateUser(t, app.DB, "pat@example.com")
+
+	reactivation, _, err := middleware.GenerateReactivationJWT(&user, app.Cfg)
+	if err != nil {
+		t.Fatalf("generate reactivation token: %v", err)
+	}
+
+	tokens := map[string]struct {
+		token string
+		valid bool
+	}{
+		"session":      {app.Token(t, user), true},
+		"reactivation": {reactivation, false},
+	}
+	for name, tt := range tokens {
+		t.Run(name, func(t *testing.T) {
+			resp := app.Do(t, fiber.MethodPost, "/api/v1/auth/validate", "", fiber.Map{"token": tt.token})
+			testapp.ExpectStatus(t, resp, fiber.StatusOK)
+
+			var body testapp.Response[validateData]
+			testapp.Decode(t, resp, &body)
+			if body.Data.Valid != tt.valid {
+				t.Errorf("valid = %v, want %v", body.Data.Valid, tt.valid)
+			}
+			if !tt.valid && body.Data.ErrorCode != models.ErrInvalidToken {
+				t.Errorf("error_code = %q, want %q", body.Data.ErrorCode, models.ErrInvalidToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"taskflow-api/internal/config"
+)
+
+// S3Storage writes files to an S3 or S3-compatible bucket. Requests are signed
+// with AWS Signature Version 4.
+type S3Storage struct {
+	client          *http.Client
+	bucketURL       string
+	publicURL       string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// NewS3Storage uses the bucket's AWS URL, or the bucket as a path below a
+// custom endpoint (e.g. MinIO). Files are served from STORAGE_PUBLIC_URL when
+// it is an absolute URL, such as a CDN in front of the bucket, and from the
+// bucket otherwise.
+func NewS3Storage(cfg config.StorageConfig) (*S3Storage, error) {
+	if cfg.S3Bucket == "" || cfg.S3Region == "" {
+		return nil, errors.New("STORAGE_S3_BUCKET and STORAGE_S3_REGION are required for the s3 driver")
+	}
+	if cfg.S3AccessKeyID == "" || cfg.S3SecretAccessKey == "" {
+		return nil, errors.New("STORAGE_S3_ACCESS_KEY_ID and STORAGE_S3_SECRET_ACCESS_KEY are required for the s3 driver")
+	}
+
+	bucketURL := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", cfg.S3Bucket, cfg.S3Region)
+	if cfg.S3Endpoint != "" {
+		bucketURL = strings.TrimSuffix(cfg.S3Endpoint, "/") + "/" + cfg.S3Bucket
+	}
+	publicURL := bucketURL
+	if strings.Contains(cfg.PublicURL, "://") {
+		publicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+	}
+
+	return &S3Storage{
+		client:          &http.Client{Timeout: 30 * time.Second},
+		bucketURL:       bucketURL,
+		publicURL:       publicURL,
+		region:          cfg.S3Region,
+		accessKeyID:     cfg.S3AccessKeyID,
+		secretAccessKey: cfg.S3SecretAccessKey,
+		sessionToken:    cfg.S3SessionToken,
+	}, nil
+}
+
+// Save uploads r to key, a slash-separated path inside the bucket
+func (s *S3Storage) Save(key string, r io.Reader) (string, error) {
+	// S3 needs the length and the payload hash up front
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	key = path.Clean("/" + key)
+	req, err := http.NewRequest(http.MethodPut, s.bucketURL+escapePath(key), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if err := s.do(req, body); err != nil {
+		return "", err
+	}
+
+	return s.publicURL + key, nil
+}
+
+// Delete removes the object behind a URL returned by Save
+func (s *S3Storage) Delete(url string) error {
+	key, ok := strings.CutPrefix(url, s.publicURL+"/")
+	if !ok {
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, s.bucketURL+escapePath(path.Clean("/"+key)), nil)
+	if err != nil {
+		return err
+	}
+	return s.do(req, nil)
+}
+
+func (s *S3Storage) do(req *http.Request, body []byte) error {
+	s.sign(req, body, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Deleting a missing object is not an error
+	if resp.StatusCode/100 == 2 || (req.Method == http.MethodDelete && resp.StatusCode == http.StatusNotFound) {
+		return nil
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, bytes.TrimSpace(message))
+}
+
+// sign adds an AWS Signature Version 4 Authorization header covering the host,
+// every header already set and the payload hash. Temporary credentials also
+// send and sign their session token.
+func (s *S3Storage) sign(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// escapePath percent-encodes everything but unreserved characters and
+// slashes, as Signature Version 4 expects of object keys
+func escapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
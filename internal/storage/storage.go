@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"taskflow-api/internal/config"
+)
+
+// Storage persists uploaded files and returns the URL they are served from
+type Storage interface {
+	Save(key string, r io.Reader) (string, error)
+	// Delete removes a file by the URL Save returned for it. URLs the storage
+	// did not hand out, and files that are already gone, are ignored.
+	Delete(url string) error
+}
+
+// New returns the storage backend selected by STORAGE_DRIVER
+func New(cfg config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case config.StorageDriverLocal:
+		return NewLocalStorage(cfg.LocalDir, cfg.PublicURL), nil
+	case config.StorageDriverS3:
+		return NewS3Storage(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", cfg.Driver)
+	}
+}
+
+// LocalStorage writes files below a directory on disk that the API serves
+// as static files
+type LocalStorage struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalStorage(dir, publicURL string) *LocalStorage {
+	return &LocalStorage{
+		dir:       dir,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// Save writes r to key, a slash-separated path relative to the storage root
+func (s *LocalStorage) Save(key string, r io.Reader) (string, error) {
+	target := filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+key)))
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		os.Remove(target)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(target)
+		return "", err
+	}
+
+	return s.publicURL + path.Clean("/"+key), nil
+}
+
+// Delete removes the file behind a URL returned by Save
+func (s *LocalStorage) Delete(url string) error {
+	key, ok := strings.CutPrefix(url, s.publicURL+"/")
+	if !ok {
+		return nil
+	}
+
+	target := filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+key)))
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalSaveAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStorage(dir, "/uploads/")
+
+	url, err := s.Save("avatars/me.png", strings.NewReader("image"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != "/uploads/avatars/me.png" {
+		t.Errorf("url = %q, want /uploads/avatars/me.png", url)
+	}
+	file := filepath.Join(dir, "avatars", "me.png")
+	if _, err := os.Stat(file); err != nil {
+		t.Fatalf("saved file: %v", err)
+	}
+
+	if err := s.Delete(url); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("file still exists after delete (err %v)", err)
+	}
+	// Already gone, or never ours
+	if err := s.Delete(url); err != nil {
+		t.Errorf("deleting a missing file: %v", err)
+	}
+	if err := s.Delete("https://gravatar.example/me.png"); err != nil {
+		t.Errorf("deleting a foreign URL: %v", err)
+	}
+}
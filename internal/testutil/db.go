@@ -0,0 +1,164 @@
+// Package testutil sets up databases and fixtures for tests.
+package testutil
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"taskflow-api/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DatabaseURLEnv names the PostgreSQL URL that database tests run against.
+// Tests that need a database are skipped when it is unset.
+const DatabaseURLEnv = "TEST_DATABASE_URL"
+
+// OpenDB connects to the test database and applies every migration to a
+// fresh schema, which is dropped when the test finishes
+func OpenDB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	rawURL := os.Getenv(DatabaseURLEnv)
+	if rawURL == "" {
+		t.Skipf("%s not set", DatabaseURLEnv)
+	}
+
+	admin, err := open(rawURL)
+	if err != nil {
+		t.Fatalf("connect to test database: %v", err)
+	}
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("create test schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	// Every pooled connection resolves names in the test schema first
+	dsn, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("parse %s: %v", DatabaseURLEnv, err)
+	}
+	query := dsn.Query()
+	query.Set("search_path", schema+",public")
+	dsn.RawQuery = query.Encode()
+
+	db, err := open(dsn.String())
+	if err != nil {
+		t.Fatalf("connect to test schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if err := migrate(db); err != nil {
+		t.Fatalf("migrate test schema: %v", err)
+	}
+	return db
+}
+
+func open(dsn string) (*gorm.DB, error) {
+	// The simple protocol lets a migration run as a single multi-statement Exec
+	return gorm.Open(postgres.New(postgres.Config{DSN: dsn, PreferSimpleProtocol: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+}
+
+// migrate runs the Up section of every goose migration in order
+func migrate(db *gorm.DB) error {
+	files, err := filepath.Glob(filepath.Join(migrationsDir(), "*.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		up, _, _ := strings.Cut(string(content), "-- +goose Down")
+		if err := db.Exec(up).Error; err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+	}
+	return nil
+}
+
+// migrationsDir finds the repository's migrations directory from this file
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations")
+}
+
+// CreateUser inserts an active user with the given email
+func CreateUser(t testing.TB, db *gorm.DB, email string) models.User {
+	t.Helper()
+
+	user := models.User{
+		Email:        email,
+		PasswordHash: "not-a-real-hash",
+		FirstName:    "Test",
+		LastName:     "User",
+	}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user %s: %v", email, err)
+	}
+	return user
+}
+
+// CreateAdmin inserts an active admin with the given email
+func CreateAdmin(t testing.TB, db *gorm.DB, email string) models.User {
+	t.Helper()
+
+	user := CreateUser(t, db, email)
+	if err := db.Model(&user).Update("is_admin", true).Error; err != nil {
+		t.Fatalf("make %s an admin: %v", email, err)
+	}
+	return user
+}
+
+// CreateProject inserts a project owned by ownerID
+func CreateProject(t testing.TB, db *gorm.DB, ownerID uuid.UUID, name string) models.Project {
+	t.Helper()
+
+	project := models.Project{Name: name, OwnerID: ownerID}
+	if err := db.Create(&project).Error; err != nil {
+		t.Fatalf("create project %s: %v", name, err)
+	}
+	return project
+}
+
+// CreateTask inserts a todo task in the project, created by the project owner
+func CreateTask(t testing.TB, db *gorm.DB, project models.Project, title string, assigneeID *uuid.UUID) models.Task {
+	t.Helper()
+
+	task := models.Task{
+		Title:      title,
+		ProjectID:  project.ID,
+		CreatedBy:  project.OwnerID,
+		AssigneeID: assigneeID,
+		Status:     models.TaskStatusTodo,
+		Priority:   models.TaskPriorityMedium,
+	}
+	if err := db.Create(&task).Error; err != nil {
+		t.Fatalf("create task %s: %v", title, err)
+	}
+	return task
+}
@@ -0,0 +1,185 @@
+// Package testapp runs the full API against a test database for handler tests.
+package testapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/handlers"
+	"taskflow-api/internal/mailer"
+	"taskflow-api/internal/middleware"
+	"taskflow-api/internal/models"
+	"taskflow-api/internal/routes"
+	"taskflow-api/internal/testutil"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// App is the API wired up the way cmd/main.go does it, backed by a fresh
+// test schema. Queued emails are delivered to Outbox.
+type App struct {
+	*fiber.App
+	DB     *gorm.DB
+	Cfg    *config.Config
+	Mail   *mailer.Queue
+	Outbox *Outbox
+}
+
+// New builds the API on a migrated test database. It skips the test when no
+// test database is configured.
+func New(t testing.TB) *App {
+	t.Helper()
+
+	db := testutil.OpenDB(t)
+	cfg := Config(t)
+
+	outbox := &Outbox{}
+	mail := mailer.NewQueue(outbox, cfg.Mail.QueueSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go mail.Run(ctx, 1)
+
+	app := fiber.New()
+	routes.SetupRoutes(app, db, cfg, mail, handlers.NewTaskViewRecorder(db))
+
+	return &App{App: app, DB: db, Cfg: cfg, Mail: mail, Outbox: outbox}
+}
+
+// Outbox is a mailer that keeps the messages it is asked to send
+type Outbox struct {
+	mu       sync.Mutex
+	messages []mailer.Message
+}
+
+func (o *Outbox) Send(_ context.Context, msg mailer.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = append(o.messages, msg)
+	return nil
+}
+
+// To waits briefly for want messages to the address to be delivered and
+// returns the messages sent to it
+func (o *Outbox) To(t testing.TB, address string, want int) []mailer.Message {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		var sent []mailer.Message
+		o.mu.Lock()
+		for _, msg := range o.messages {
+			if msg.To == address {
+				sent = append(sent, msg)
+			}
+		}
+		o.mu.Unlock()
+
+		if len(sent) >= want || time.Now().After(deadline) {
+			return sent
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Config loads the configuration with test settings. Variables already set
+// in the environment still apply.
+func Config(t testing.TB) *config.Config {
+	t.Helper()
+
+	t.Setenv("ENV", "test")
+	t.Setenv("JWT_SECRET", "test-secret-that-is-long-enough-for-hs256")
+	t.Setenv("STORAGE_LOCAL_DIR", t.TempDir())
+	t.Setenv("MAIL_DRIVER", config.MailDriverLog)
+	return config.LoadConfig()
+}
+
+// Token returns a session token for the user
+func (a *App) Token(t testing.TB, user models.User) string {
+	t.Helper()
+
+	token, err := middleware.GenerateJWT(&user, a.Cfg)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	return token
+}
+
+// Do sends body as JSON, authenticated with token when it is not empty
+func (a *App) Do(t testing.TB, method, path, token string, body interface{}) *http.Response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encode request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	return a.send(t, req, token)
+}
+
+// DoForm sends form as a urlencoded body, authenticated with token when it is
+// not empty
+func (a *App) DoForm(t testing.TB, method, path, token string, form url.Values) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+	return a.send(t, req, token)
+}
+
+func (a *App) send(t testing.TB, req *http.Request, token string) *http.Response {
+	t.Helper()
+
+	if token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+	resp, err := a.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", req.Method, req.URL.Path, err)
+	}
+	return resp
+}
+
+// Response is a success or list response with typed data
+type Response[T any] struct {
+	Message    string                    `json:"message"`
+	Data       T                         `json:"data"`
+	Pagination models.PaginationResponse `json:"pagination"`
+}
+
+// Decode reads a JSON response into v
+func Decode(t testing.TB, resp *http.Response, v interface{}) {
+	t.Helper()
+
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+}
+
+// ExpectStatus fails the test unless the response has the wanted status
+func ExpectStatus(t testing.TB, resp *http.Response, want int) {
+	t.Helper()
+
+	if resp.StatusCode != want {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("%s %s: status = %d, want %d: %s", resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, want, body)
+	}
+}
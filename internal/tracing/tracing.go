@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+	"log"
+
+	"taskflow-api/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TracerName is the instrumentation name used for spans created by the API
+const TracerName = "taskflow-api"
+
+// Init configures the global tracer provider to export spans over OTLP.
+// When no endpoint is configured, tracing stays disabled but incoming trace
+// context is still propagated. The returned function flushes and stops the exporter.
+func Init(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Tracing.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Tracing.Endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", cfg.Tracing.ServiceName),
+			attribute.String("deployment.environment", cfg.Environment),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	log.Printf("✅ Tracing enabled, exporting to %s", cfg.Tracing.Endpoint)
+	return provider.Shutdown, nil
+}
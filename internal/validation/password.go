@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"taskflow-api/internal/config"
+)
+
+// CheckPassword verifies a password against the configured policy and
+// returns an error describing every rule it fails
+func CheckPassword(policy config.PasswordPolicy, password string) error {
+	var failures []string
+
+	if utf8.RuneCountInString(password) < policy.MinLength {
+		failures = append(failures, fmt.Sprintf("be at least %d characters long", policy.MinLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
+	}
+
+	if policy.RequireUpper && !hasUpper {
+		failures = append(failures, "contain an uppercase letter")
+	}
+	if policy.RequireLower && !hasLower {
+		failures = append(failures, "contain a lowercase letter")
+	}
+	if policy.RequireDigit && !hasDigit {
+		failures = append(failures, "contain a digit")
+	}
+	if policy.RequireSpecial && !hasSpecial {
+		failures = append(failures, "contain a special character")
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("password must %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
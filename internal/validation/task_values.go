@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"log"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// taskValuesTTL is how long listed values are reused before they are
+	// reloaded, so custom values added on other instances show up
+	taskValuesTTL = 30 * time.Second
+	// taskValuesMissInterval limits how often an unknown value triggers a
+	// reload, so invalid input cannot flood the lookup tables with queries
+	taskValuesMissInterval = time.Second
+)
+
+// TaskValuesLoader reads the current statuses and priorities, in order
+type TaskValuesLoader func() (statuses, priorities []string, err error)
+
+// TaskValues holds the statuses and priorities tasks may use. They are loaded
+// from the task_statuses and task_priorities lookup tables and grow when
+// admins add custom values. Values added on another instance are picked up by
+// reloading: when the cached values are older than taskValuesTTL, and before
+// rejecting a value that is not cached.
+type TaskValues struct {
+	mu         sync.RWMutex
+	statuses   []string
+	priorities []string
+	loadedAt   time.Time
+
+	// load is nil for fixed values
+	load         TaskValuesLoader
+	reloadMu     sync.Mutex
+	ttl          time.Duration
+	missInterval time.Duration
+}
+
+// NewTaskValues returns fixed values that are never reloaded
+func NewTaskValues(statuses, priorities []string) *TaskValues {
+	return &TaskValues{
+		statuses:   statuses,
+		priorities: priorities,
+	}
+}
+
+// LoadTaskValues loads the values with load, which is called again whenever
+// they need reloading
+func LoadTaskValues(load TaskValuesLoader) (*TaskValues, error) {
+	statuses, priorities, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &TaskValues{
+		statuses:     statuses,
+		priorities:   priorities,
+		loadedAt:     time.Now(),
+		load:         load,
+		ttl:          taskValuesTTL,
+		missInterval: taskValuesMissInterval,
+	}, nil
+}
+
+// Statuses returns the task statuses in workflow order
+func (v *TaskValues) Statuses() []string {
+	v.reload(v.ttl)
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return slices.Clone(v.statuses)
+}
+
+// Priorities returns the task priorities from lowest to highest
+func (v *TaskValues) Priorities() []string {
+	v.reload(v.ttl)
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return slices.Clone(v.priorities)
+}
+
+func (v *TaskValues) HasStatus(status string) bool {
+	return v.has(func() []string { return v.statuses }, status)
+}
+
+func (v *TaskValues) HasPriority(priority string) bool {
+	return v.has(func() []string { return v.priorities }, priority)
+}
+
+// has looks value up in the list returned by values, reloading once before
+// giving up on it
+func (v *TaskValues) has(values func() []string, value string) bool {
+	v.mu.RLock()
+	found := slices.Contains(values(), value)
+	v.mu.RUnlock()
+	if found {
+		return true
+	}
+
+	v.reload(v.missInterval)
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return slices.Contains(values(), value)
+}
+
+// reload replaces the values with freshly loaded ones when they are older than
+// maxAge. On failure the cached values stay in use.
+func (v *TaskValues) reload(maxAge time.Duration) {
+	if v.load == nil {
+		return
+	}
+
+	// One reload at a time; callers that waited see its result as fresh
+	v.reloadMu.Lock()
+	defer v.reloadMu.Unlock()
+
+	v.mu.RLock()
+	fresh := time.Since(v.loadedAt) < maxAge
+	v.mu.RUnlock()
+	if fresh {
+		return
+	}
+
+	statuses, priorities, err := v.load()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.loadedAt = time.Now()
+	if err != nil {
+		log.Printf("⚠️  Failed to reload task statuses and priorities: %v", err)
+		return
+	}
+	v.statuses = statuses
+	v.priorities = priorities
+}
+
+// AddStatus appends a custom status after the existing ones
+func (v *TaskValues) AddStatus(status string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !slices.Contains(v.statuses, status) {
+		v.statuses = append(v.statuses, status)
+	}
+}
+
+// AddPriority appends a custom priority above the existing ones
+func (v *TaskValues) AddPriority(priority string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !slices.Contains(v.priorities, priority) {
+		v.priorities = append(v.priorities, priority)
+	}
+}
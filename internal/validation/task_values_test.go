@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeLoader serves values that tests can change, counting the loads
+type fakeLoader struct {
+	statuses   []string
+	priorities []string
+	err        error
+	loads      int
+}
+
+func (l *fakeLoader) load() ([]string, []string, error) {
+	l.loads++
+	return slices.Clone(l.statuses), slices.Clone(l.priorities), l.err
+}
+
+func TestTaskValuesReloadOnMiss(t *testing.T) {
+	loader := &fakeLoader{statuses: []string{"todo", "done"}, priorities: []string{"low", "high"}}
+	values, err := LoadTaskValues(loader.load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values.missInterval = 0
+
+	if !values.HasStatus("todo") || loader.loads != 1 {
+		t.Fatalf("known status: loads = %d, want 1", loader.loads)
+	}
+
+	// Another instance adds custom values
+	loader.statuses = append(loader.statuses, "blocked")
+	loader.priorities = append(loader.priorities, "critical")
+	if !values.HasStatus("blocked") {
+		t.Error("status added elsewhere was rejected")
+	}
+	if !values.HasPriority("critical") {
+		t.Error("priority added elsewhere was rejected")
+	}
+	if !slices.Contains(values.Statuses(), "blocked") {
+		t.Errorf("Statuses() = %v, want it to include blocked", values.Statuses())
+	}
+	if values.HasStatus("unknown") {
+		t.Error("unknown status accepted")
+	}
+}
+
+func TestTaskValuesReloadLimits(t *testing.T) {
+	loader := &fakeLoader{statuses: []string{"todo"}, priorities: []string{"low"}}
+	values, err := LoadTaskValues(loader.load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values.missInterval = time.Hour
+	values.ttl = time.Hour
+
+	// Fresh values are trusted, however many misses there are
+	for range 10 {
+		values.HasStatus("unknown")
+	}
+	values.Statuses()
+	if loader.loads != 1 {
+		t.Errorf("loads = %d, want only the initial one", loader.loads)
+	}
+
+	// Stale values are reloaded, and a failed reload keeps the cached ones
+	values.ttl = 0
+	loader.err = errors.New("database down")
+	if got := values.Statuses(); !slices.Equal(got, []string{"todo"}) {
+		t.Errorf("Statuses() after a failed reload = %v, want [todo]", got)
+	}
+	if loader.loads != 2 {
+		t.Errorf("loads = %d, want 2", loader.loads)
+	}
+}
+
+func TestFixedTaskValues(t *testing.T) {
+	values := NewTaskValues([]string{"todo"}, []string{"low"})
+	values.AddStatus("blocked")
+	if !values.HasStatus("blocked") || values.HasStatus("unknown") {
+		t.Errorf("statuses = %v", values.Statuses())
+	}
+}
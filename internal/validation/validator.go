@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"taskflow-api/internal/config"
+	"taskflow-api/internal/models"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// New returns a validator with the configurable tags registered:
+// project_name, project_description, project_color, task_title,
+// task_description, and task_status and task_priority backed by values.
+// Tags on a models.NullableString apply to its value when one is set.
+func New(cfg *config.Config, values *TaskValues) *validator.Validate {
+	validate := validator.New()
+	validate.RegisterCustomTypeFunc(nullableValue, models.NullableString{})
+	registerMaxLength(validate, "project_name", cfg.Projects.MaxNameLength)
+	registerMaxLength(validate, "project_description", cfg.Projects.MaxDescriptionLength)
+	registerMaxLength(validate, "task_title", cfg.Tasks.MaxTitleLength)
+	registerMaxLength(validate, "task_description", cfg.Tasks.MaxDescriptionLength)
+	register(validate, "project_color", colorValidator(cfg.Projects.ColorPalette))
+	register(validate, "task_status", func(fl validator.FieldLevel) bool {
+		return values.HasStatus(fl.Field().String())
+	})
+	register(validate, "task_priority", func(fl validator.FieldLevel) bool {
+		return values.HasPriority(fl.Field().String())
+	})
+	return validate
+}
+
+// registerMaxLength adds a tag that rejects strings longer than max characters
+func registerMaxLength(validate *validator.Validate, tag string, max int) {
+	register(validate, tag, func(fl validator.FieldLevel) bool {
+		return utf8.RuneCountInString(fl.Field().String()) <= max
+	})
+}
+
+// colorValidator accepts colors from the palette, or any hex color when the
+// palette is empty. Palette matching ignores case.
+func colorValidator(palette []string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		color := fl.Field().String()
+		if len(palette) == 0 {
+			return config.HexColorPattern.MatchString(color)
+		}
+		return slices.ContainsFunc(palette, func(allowed string) bool {
+			return strings.EqualFold(allowed, color)
+		})
+	}
+}
+
+// nullableValue unwraps a NullableString for validation; omitted and null
+// values validate as empty
+func nullableValue(field reflect.Value) interface{} {
+	if n, ok := field.Interface().(models.NullableString); ok && n.Value != nil {
+		return *n.Value
+	}
+	return ""
+}
+
+func register(validate *validator.Validate, tag string, fn validator.Func) {
+	if err := validate.RegisterValidation(tag, fn); err != nil {
+		panic(err)
+	}
+}
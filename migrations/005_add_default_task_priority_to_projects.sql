@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add default task priority to projects
+ALTER TABLE projects ADD COLUMN default_task_priority task_priority DEFAULT 'medium';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop default task priority from projects
+ALTER TABLE projects DROP COLUMN IF EXISTS default_task_priority;
+
+-- +goose StatementEnd
\ No newline at end of file
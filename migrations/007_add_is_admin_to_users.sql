@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add admin flag to users
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN DEFAULT false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop admin flag from users
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
+
+-- +goose StatementEnd
\ No newline at end of file
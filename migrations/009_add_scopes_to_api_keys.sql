@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add scopes to api_keys; existing keys keep full access
+ALTER TABLE api_keys ADD COLUMN scopes TEXT NOT NULL DEFAULT '';
+UPDATE api_keys SET scopes = 'projects:write tasks:write users:write';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop scopes from api_keys
+ALTER TABLE api_keys DROP COLUMN IF EXISTS scopes;
+
+-- +goose StatementEnd
\ No newline at end of file
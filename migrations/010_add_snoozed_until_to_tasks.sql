@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add snoozed_until to tasks
+ALTER TABLE tasks ADD COLUMN snoozed_until TIMESTAMP WITH TIME ZONE;
+CREATE INDEX idx_tasks_snoozed_until ON tasks(snoozed_until);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop snoozed_until from tasks
+DROP INDEX IF EXISTS idx_tasks_snoozed_until;
+ALTER TABLE tasks DROP COLUMN IF EXISTS snoozed_until;
+
+-- +goose StatementEnd
\ No newline at end of file
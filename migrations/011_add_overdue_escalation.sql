@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add overdue escalation setting to projects
+ALTER TABLE projects ADD COLUMN auto_escalate_overdue BOOLEAN DEFAULT false;
+
+-- Track when a task was last escalated
+ALTER TABLE tasks ADD COLUMN escalated_at TIMESTAMP WITH TIME ZONE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop overdue escalation columns
+ALTER TABLE tasks DROP COLUMN IF EXISTS escalated_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS auto_escalate_overdue;
+
+-- +goose StatementEnd
\ No newline at end of file
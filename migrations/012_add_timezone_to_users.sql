@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add timezone to users for due-date day boundaries
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop timezone column
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+
+-- +goose StatementEnd
\ No newline at end of file
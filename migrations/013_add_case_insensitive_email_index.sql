@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Emails that only differ in case or surrounding spaces would collide in the
+-- index below. Merging accounts is a manual decision, so stop here and name
+-- them instead of failing halfway through the UPDATE.
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(normalized, ', ' ORDER BY normalized) INTO duplicates
+    FROM (
+        SELECT LOWER(TRIM(email)) AS normalized
+        FROM users
+        GROUP BY LOWER(TRIM(email))
+        HAVING COUNT(*) > 1
+    ) AS collisions;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'users with case-insensitively duplicate emails must be merged or renamed first: %', duplicates;
+    END IF;
+END $$;
+
+-- Store emails lowercased and enforce uniqueness regardless of case
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+CREATE UNIQUE INDEX idx_users_email_lower ON users (LOWER(email));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop case-insensitive email index
+DROP INDEX IF EXISTS idx_users_email_lower;
+
+-- +goose StatementEnd
\ No newline at end of file
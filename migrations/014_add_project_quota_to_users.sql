@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add per-user project quota override
+ALTER TABLE users ADD COLUMN project_quota INTEGER;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop project quota override
+ALTER TABLE users DROP COLUMN IF EXISTS project_quota;
+
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add archive timestamp to tasks
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop archive timestamp from tasks
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived_at;
+
+-- +goose StatementEnd
\ No newline at end of file
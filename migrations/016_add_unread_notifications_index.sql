@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Index unread notifications per user for badge counts
+CREATE INDEX idx_notifications_user_unread ON notifications(user_id) WHERE read = false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop unread notifications index
+DROP INDEX IF EXISTS idx_notifications_user_unread;
+
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add per-user email notification preference
+ALTER TABLE users ADD COLUMN email_notifications BOOLEAN NOT NULL DEFAULT true;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop email notification preference
+ALTER TABLE users DROP COLUMN IF EXISTS email_notifications;
+
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create notification preferences table; users without a row get the defaults
+CREATE TABLE notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    task_assigned BOOLEAN NOT NULL DEFAULT true,
+    task_overdue BOOLEAN NOT NULL DEFAULT true,
+    project_transferred BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop notification preferences table
+DROP TABLE IF EXISTS notification_preferences;
+
+-- +goose StatementEnd
\ No newline at end of file
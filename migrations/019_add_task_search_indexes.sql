@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Enable trigram matching so ILIKE searches can use an index
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Create trigram indexes for task search
+CREATE INDEX idx_tasks_title_trgm ON tasks USING GIN (title gin_trgm_ops);
+CREATE INDEX idx_tasks_description_trgm ON tasks USING GIN (description gin_trgm_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop task search indexes; the extension is left in place
+DROP INDEX IF EXISTS idx_tasks_description_trgm;
+DROP INDEX IF EXISTS idx_tasks_title_trgm;
+
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add project version for optimistic locking
+ALTER TABLE projects ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop project version
+ALTER TABLE projects DROP COLUMN IF EXISTS version;
+
+-- +goose StatementEnd
\ No newline at end of file
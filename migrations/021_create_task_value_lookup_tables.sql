@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create lookup tables listing the task statuses and priorities; custom values
+-- are added to the enum types and recorded here
+CREATE TABLE task_statuses (
+    name VARCHAR(30) PRIMARY KEY,
+    position INTEGER NOT NULL,
+    built_in BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE task_priorities (
+    name VARCHAR(30) PRIMARY KEY,
+    position INTEGER NOT NULL,
+    built_in BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Seed the built-in values
+INSERT INTO task_statuses (name, position, built_in) VALUES
+    ('todo', 1, true),
+    ('in_progress', 2, true),
+    ('done', 3, true),
+    ('cancelled', 4, true);
+
+INSERT INTO task_priorities (name, position, built_in) VALUES
+    ('low', 1, true),
+    ('medium', 2, true),
+    ('high', 3, true),
+    ('urgent', 4, true);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop task value lookup tables; custom enum values cannot be removed
+DROP TABLE IF EXISTS task_priorities;
+DROP TABLE IF EXISTS task_statuses;
+
+-- +goose StatementEnd
\ No newline at end of file
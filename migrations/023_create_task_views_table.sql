@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create task views table
+CREATE TABLE task_views (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, task_id)
+);
+
+-- Index for listing a user's most recent views
+CREATE INDEX idx_task_views_user_viewed_at ON task_views(user_id, viewed_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop task views table
+DROP TABLE IF EXISTS task_views;
+
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create impersonation logs table; rows are kept when users are deleted
+CREATE TABLE impersonation_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    admin_id UUID NOT NULL REFERENCES users(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    reason TEXT,
+    ip_address VARCHAR(45) NOT NULL,
+    user_agent TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for looking up who impersonated whom
+CREATE INDEX idx_impersonation_logs_admin_id ON impersonation_logs(admin_id);
+CREATE INDEX idx_impersonation_logs_user_id ON impersonation_logs(user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop impersonation logs table
+DROP TABLE IF EXISTS impersonation_logs;
+
+-- +goose StatementEnd
\ No newline at end of file
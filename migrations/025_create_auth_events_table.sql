@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create auth events table; rows are kept when users are deleted
+CREATE TABLE auth_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id),
+    email VARCHAR(255) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    user_agent TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for querying a user's events and time ranges
+CREATE INDEX idx_auth_events_user_id_created_at ON auth_events(user_id, created_at DESC);
+CREATE INDEX idx_auth_events_created_at ON auth_events(created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop auth events table
+DROP TABLE IF EXISTS auth_events;
+
+-- +goose StatementEnd
\ No newline at end of file
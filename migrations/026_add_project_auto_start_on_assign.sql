@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add setting to start todo tasks when they are assigned
+ALTER TABLE projects ADD COLUMN auto_start_on_assign BOOLEAN DEFAULT false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop auto start setting
+ALTER TABLE projects DROP COLUMN IF EXISTS auto_start_on_assign;
+
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Record who created each task; existing tasks have no known creator
+ALTER TABLE tasks ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Create index for listing the tasks a user created
+CREATE INDEX idx_tasks_created_by ON tasks(created_by);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop task creator
+DROP INDEX IF EXISTS idx_tasks_created_by;
+ALTER TABLE tasks DROP COLUMN IF EXISTS created_by;
+
+-- +goose StatementEnd
\ No newline at end of file
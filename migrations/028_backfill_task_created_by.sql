@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Attribute tasks without a recorded creator to their project owner
+UPDATE tasks SET created_by = projects.owner_id
+FROM projects
+WHERE tasks.project_id = projects.id AND tasks.created_by IS NULL;
+
+-- Require a creator; rows are kept when users are deleted
+ALTER TABLE tasks ALTER COLUMN created_by SET NOT NULL;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_created_by_fkey;
+ALTER TABLE tasks ADD CONSTRAINT tasks_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Make the creator optional again; backfilled creators are left in place
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_created_by_fkey;
+ALTER TABLE tasks ADD CONSTRAINT tasks_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE tasks ALTER COLUMN created_by DROP NOT NULL;
+
+-- +goose StatementEnd
\ No newline at end of file
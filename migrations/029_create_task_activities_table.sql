@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Create task activities table; the log goes with its task
+CREATE TABLE task_activities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id),
+    action VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create index for reading a task's history
+CREATE INDEX idx_task_activities_task_id_created_at ON task_activities(task_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop task activities table
+DROP TABLE IF EXISTS task_activities;
+
+-- +goose StatementEnd
\ No newline at end of file
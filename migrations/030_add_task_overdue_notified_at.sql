@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Track when the assignee was told a task is overdue
+ALTER TABLE tasks ADD COLUMN overdue_notified_at TIMESTAMP WITH TIME ZONE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop overdue notice column
+ALTER TABLE tasks DROP COLUMN IF EXISTS overdue_notified_at;
+
+-- +goose StatementEnd
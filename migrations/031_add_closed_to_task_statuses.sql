@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Mark the statuses that close a task; every other status counts as open
+ALTER TABLE task_statuses ADD COLUMN closed BOOLEAN NOT NULL DEFAULT false;
+UPDATE task_statuses SET closed = true WHERE name IN ('done', 'cancelled');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Drop closed status flag
+ALTER TABLE task_statuses DROP COLUMN IF EXISTS closed;
+
+-- +goose StatementEnd